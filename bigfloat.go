@@ -0,0 +1,370 @@
+package goarith
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+)
+
+// *BigFloat implements Number as a floating-point number of arbitrary
+// precision.
+//
+// An operation of *BigFloat and another Number results in a *BigFloat.
+// A Float64 operand is converted into a big.Float of 53-bit precision,
+// and an integer operand into a big.Float of enough precision to hold it
+// exactly.  The result has the maximum precision of the operands.
+// Since big.Float has no NaN, an operation which would result in NaN,
+// e.g. Inf - Inf, returns Float64(NaN) instead.
+type BigFloat big.Float
+
+// DefaultBigFloatPrec is the precision which NewBigFloat uses when it is
+// given 0 as the precision.
+const DefaultBigFloatPrec = 256
+
+// NewBigFloat converts x into a *BigFloat of the precision prec,
+// rounding x if necessary.  If prec is 0, it uses DefaultBigFloatPrec.
+// It panics if x is NaN.
+func NewBigFloat(x Number, prec uint) *BigFloat {
+	if prec == 0 {
+		prec = DefaultBigFloatPrec
+	}
+	y := toBigFloat(x)
+	if y == nil {
+		panic(fmt.Sprintf("NewBigFloat(%s, %d)", x.String(), prec))
+	}
+	return (*BigFloat)(new(big.Float).SetPrec(prec).Set(y))
+}
+
+// toBigFloat returns the big.Float value of n, which is exact for any
+// finite n but a *Decimal, which is rounded to the precision of at least
+// 64 bits as big.Float.SetRat does.  It returns the underlying big.Float
+// for *BigFloat, which must not be modified.  It returns nil if n is NaN.
+func toBigFloat(n Number) *big.Float {
+	switch x := n.(type) {
+	case Int32:
+		return new(big.Float).SetInt64(int64(x))
+	case Int64:
+		return new(big.Float).SetInt64(int64(x))
+	case Float64:
+		if math.IsNaN(float64(x)) {
+			return nil
+		}
+		return new(big.Float).SetFloat64(float64(x))
+	case *BigInt:
+		return new(big.Float).SetInt((*big.Int)(x))
+	case *BigFloat:
+		return (*big.Float)(x)
+	case *Decimal:
+		return new(big.Float).SetRat(x.rat())
+	}
+	panic(fmt.Sprintf("toBigFloat(%s)", n.String()))
+}
+
+// bigFloatPrec returns the maximum precision of a and b which are
+// *BigFloat.  It returns 0 if neither is *BigFloat.
+func bigFloatPrec(a, b Number) uint {
+	var prec uint
+	if x, ok := a.(*BigFloat); ok {
+		prec = (*big.Float)(x).Prec()
+	}
+	if y, ok := b.(*BigFloat); ok && (*big.Float)(y).Prec() > prec {
+		prec = (*big.Float)(y).Prec()
+	}
+	return prec
+}
+
+// bigFloatOp returns f(a, b) computed in big.Float, where a or b is a
+// *BigFloat.  If the result is NaN, it returns Float64(NaN).
+func bigFloatOp(a, b Number, f func(z, x, y *big.Float) *big.Float) (result Number) {
+	x, y := toBigFloat(a), toBigFloat(b)
+	if x == nil || y == nil {
+		return Float64(math.NaN())
+	}
+	defer func() {
+		if e := recover(); e != nil {
+			if _, ok := e.(big.ErrNaN); !ok {
+				panic(e)
+			}
+			result = Float64(math.NaN())
+		}
+	}()
+	return (*BigFloat)(f(new(big.Float), x, y))
+}
+
+// cmpBigFloat compares a and b, where a or b is a *BigFloat.
+// It returns 0 if a or b is NaN.
+func cmpBigFloat(a, b Number) int {
+	if isDecimal(a, b) {
+		return cmpDecimal(a, b)
+	}
+	x, y := toBigFloat(a), toBigFloat(b)
+	if x == nil || y == nil {
+		return 0
+	}
+	return x.Cmp(y)
+}
+
+// cmpAbsBigFloat compares |a| and |b|, where a or b is a *BigFloat.
+// It returns 0 if a or b is NaN.
+func cmpAbsBigFloat(a, b Number) int {
+	if isDecimal(a, b) {
+		return cmpAbsDecimal(a, b)
+	}
+	x, y := toBigFloat(a), toBigFloat(b)
+	if x == nil || y == nil {
+		return 0
+	}
+	return new(big.Float).Abs(x).Cmp(new(big.Float).Abs(y))
+}
+
+// rquoBigFloat returns a / b rounded to a Float64, where a or b is a
+// *BigFloat.
+func rquoBigFloat(a, b Number) Float64 {
+	switch q := bigFloatOp(a, b, (*big.Float).Quo).(type) {
+	case *BigFloat:
+		f, _ := (*big.Float)(q).Float64()
+		return Float64(f)
+	case Float64:
+		return q
+	}
+	panic("not reached")
+}
+
+// quoRemBigFloat returns the truncated quotient and the remainder of a
+// and b, where a or b is a *BigFloat.  The quotient will be an Int32,
+// Int64 or BigInt and the remainder will be a *BigFloat, both computed
+// exactly, unless a or b is infinite or NaN or b is zero, in which case
+// they are computed as Float64.
+func quoRemBigFloat(a, b Number) (Number, Number) {
+	x, y := ToBigRat(a), ToBigRat(b)
+	if x == nil || y == nil || y.Sign() == 0 {
+		return asFloat64(a).quoRemFloat64(asFloat64(b))
+	}
+	n := new(big.Int).Mul(x.Num(), y.Denom())
+	d := new(big.Int).Mul(x.Denom(), y.Num())
+	q := n.Quo(n, d)
+	r := new(big.Rat).SetInt(q)
+	r.Sub(x, r.Mul(r, y))
+	z := new(big.Float).SetPrec(bigFloatPrec(a, b)).SetRat(r)
+	return (*BigInt)(q).reduce(), (*BigFloat)(z)
+}
+
+// quoExactBigFloat is the same as QuoExact, where a or b is a *BigFloat.
+func quoExactBigFloat(a, b Number) (Number, bool) {
+	q, r := quoRemBigFloat(a, b)
+	switch z := r.(type) {
+	case *BigFloat:
+		if (*big.Float)(z).Sign() != 0 {
+			return nil, false
+		}
+	case Float64:
+		if z != 0 {
+			return nil, false
+		}
+	}
+	return q, true
+}
+
+// quoRoundRat returns a / b rounded to an integer according to mode,
+// computed exactly through big.Rat.  If a or b is infinite or NaN or b
+// is zero, it computes the result through Float64.
+func quoRoundRat(a, b Number, mode RoundingMode) Number {
+	x, y := ToBigRat(a), ToBigRat(b)
+	if x == nil || y == nil || y.Sign() == 0 {
+		return asFloat64(a).RQuoRound(asFloat64(b), mode)
+	}
+	n := new(big.Int).Mul(x.Num(), y.Denom())
+	d := new(big.Int).Mul(x.Denom(), y.Num())
+	return quoRoundInt(n, d, mode)
+}
+
+// String method
+
+func (a *BigFloat) String() string {
+	x := (*big.Float)(a)
+	s := x.Text('g', -1)
+	if !x.IsInf() && !strings.ContainsAny(s, ".e") {
+		s += ".0"
+	}
+	return s
+}
+
+// Int methods
+
+func (a *BigFloat) Int() (int, bool) {
+	i, exact := a.Int64()
+	j, exact2 := Int64(i).Int()
+	return j, exact && exact2
+}
+
+func (a *BigFloat) Int64() (int64, bool) {
+	i, acc := (*big.Float)(a).Int64()
+	return i, acc == big.Exact
+}
+
+func (a *BigFloat) Int32() (int32, bool) {
+	return toInt32(a.Trunc().Int64())
+}
+
+func (a *BigFloat) Uint64() (uint64, bool) {
+	u, acc := (*big.Float)(a).Uint64()
+	return u, acc == big.Exact
+}
+
+// Arithmetic methods
+
+func (a *BigFloat) Add(b Number) Number {
+	return bigFloatOp(a, b, (*big.Float).Add)
+}
+
+func (a *BigFloat) Sub(b Number) Number {
+	return bigFloatOp(a, b, (*big.Float).Sub)
+}
+
+func (a *BigFloat) Inc() Number {
+	return bigFloatOp(a, Int32(1), (*big.Float).Add)
+}
+
+func (a *BigFloat) Dec() Number {
+	return bigFloatOp(a, Int32(1), (*big.Float).Sub)
+}
+
+func (a *BigFloat) Cmp(b Number) int {
+	return cmpBigFloat(a, b)
+}
+
+func (a *BigFloat) CmpErr(b Number) (int, error) {
+	return cmpErr(a, b)
+}
+
+func (a *BigFloat) CmpAbs(b Number) int {
+	return cmpAbsBigFloat(a, b)
+}
+
+func (a *BigFloat) Mul(b Number) Number {
+	return bigFloatOp(a, b, (*big.Float).Mul)
+}
+
+func (a *BigFloat) Pow(b Number) Number {
+	return pow(a, b)
+}
+
+func (a *BigFloat) Log() Float64 {
+	return logNumber(a, math.Log, math.Ln2)
+}
+
+func (a *BigFloat) Log2() Float64 {
+	return logNumber(a, math.Log2, 1)
+}
+
+func (a *BigFloat) Log10() Float64 {
+	return logNumber(a, math.Log10, math.Log10(2))
+}
+
+func (a *BigFloat) RQuo(b Number) Float64 {
+	return rquoBigFloat(a, b)
+}
+
+func (a *BigFloat) RQuoRat(b Number) *big.Rat {
+	return rquoRat(a, b)
+}
+
+func (a *BigFloat) QuoRem(b Number) (Number, Number) {
+	return quoRemBigFloat(a, b)
+}
+
+func (a *BigFloat) FloorDivMod(b Number) (Number, Number) {
+	return floorDivMod(a, b)
+}
+
+func (a *BigFloat) RQuoRound(b Number, mode RoundingMode) Number {
+	return quoRoundRat(a, b, mode)
+}
+
+func (a *BigFloat) QuoExact(b Number) (Number, bool) {
+	return quoExactBigFloat(a, b)
+}
+
+// Other methods
+
+func (a *BigFloat) IsInteger() bool {
+	return (*big.Float)(a).IsInt()
+}
+
+func (a *BigFloat) IsOne() bool {
+	return (*big.Float)(a).Cmp(big.NewFloat(1)) == 0
+}
+
+func (a *BigFloat) IsInf(sign int) bool {
+	x := (*big.Float)(a)
+	return x.IsInf() && (sign == 0 || (sign > 0) == (x.Sign() > 0))
+}
+
+func (a *BigFloat) IsNaN() bool {
+	return false // big.Float has no NaN.
+}
+
+func (a *BigFloat) IsNegative() bool {
+	return (*big.Float)(a).Sign() < 0
+}
+
+func (a *BigFloat) IsPositive() bool {
+	return (*big.Float)(a).Sign() > 0
+}
+
+func (a *BigFloat) Signbit() bool {
+	return (*big.Float)(a).Signbit()
+}
+
+func (a *BigFloat) QuantizeTo(step Number) Number {
+	return quantize(a, step)
+}
+
+func (a *BigFloat) Combine(b Number) uint64 {
+	return combineHash(a, b)
+}
+
+func (a *BigFloat) Reduce() Number {
+	return a
+}
+
+func (a *BigFloat) CmpTotal(b Number) int {
+	return cmpTotal(a, b)
+}
+
+// round returns a rounded to an integral *BigFloat of the same
+// precision by f.
+func (a *BigFloat) round(f func(n, d *big.Int) *big.Int) Number {
+	x := (*big.Float)(a)
+	if x.IsInf() || x.IsInt() {
+		return a
+	}
+	r, _ := x.Rat(nil)
+	z := new(big.Float).SetPrec(x.Prec()).SetInt(f(r.Num(), r.Denom()))
+	return (*BigFloat)(z)
+}
+
+func (a *BigFloat) Trunc() Number {
+	return a.round(func(n, d *big.Int) *big.Int {
+		return quoRound(n, d, ToZero)
+	})
+}
+
+func (a *BigFloat) Floor() Number {
+	return a.round(func(n, d *big.Int) *big.Int {
+		return quoRound(n, d, Down)
+	})
+}
+
+func (a *BigFloat) Ceil() Number {
+	return a.round(func(n, d *big.Int) *big.Int {
+		return quoRound(n, d, Up)
+	})
+}
+
+func (a *BigFloat) Round() Number {
+	return a.round(func(n, d *big.Int) *big.Int {
+		return quoRound(n, d, ToNearestAway)
+	})
+}
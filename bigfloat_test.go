@@ -0,0 +1,101 @@
+package goarith
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+)
+
+func ExampleNewBigFloat() {
+	a := NewBigFloat(Float64(1e20), 128)
+	b := a.Add(Int32(1)).Sub(a)
+	fmt.Printf("%T %s\n", b, b)
+	c := Float64(1e20)
+	d := c.Add(Int32(1)).Sub(c)
+	fmt.Printf("%T %s\n", d, d)
+	// Output:
+	// *goarith.BigFloat 1.0
+	// goarith.Float64 0.0
+}
+
+func ExampleBigFloat_Add() {
+	a := NewBigFloat(Int32(1), 0)
+	for _, b := range []Number{Int32(2), Int64(3), Float64(0.5),
+		(*BigInt)(big.NewInt(4)), NewBigFloat(Float64(0.25), 64)} {
+		c := a.Add(b)
+		fmt.Printf("%T %s\n", c, c)
+	}
+	inf := NewBigFloat(Float64(math.Inf(1)), 0)
+	fmt.Printf("%g\n", inf.Sub(inf))
+	// Output:
+	// *goarith.BigFloat 3.0
+	// *goarith.BigFloat 4.0
+	// *goarith.BigFloat 1.5
+	// *goarith.BigFloat 5.0
+	// *goarith.BigFloat 1.25
+	// NaN
+}
+
+func ExampleBigFloat_QuoRem() {
+	a := NewBigFloat(Float64(7.5), 0)
+	q, r := a.QuoRem(Int32(2))
+	fmt.Printf("%T %s, %T %s\n", q, q, r, r)
+	q, r = Int32(-7).QuoRem(NewBigFloat(Float64(2.5), 0))
+	fmt.Println(q, r)
+	fmt.Println(a.RQuo(Int32(2)))
+	fmt.Println(a.RQuoRound(Int32(2), ToNearestEven))
+	fmt.Println(a.Round(), NewBigFloat(Float64(-7.5), 0).Round(), a.Floor(), a.Ceil(), a.Trunc())
+	// Output:
+	// goarith.Int32 3, *goarith.BigFloat 1.5
+	// -2 -2.0
+	// 3.75
+	// 4
+	// 8.0 -8.0 7.0 8.0 7.0
+}
+
+func TestBigFloatMixed(t *testing.T) {
+	a := NewBigFloat(Float64(2.5), 0)
+	for _, b := range []Number{Int32(2), Int64(2), Float64(2),
+		(*BigInt)(big.NewInt(2))} {
+		if a.Cmp(b) != 1 || b.Cmp(a) != -1 {
+			t.Errorf("Cmp(%s, %s)", a, b)
+		}
+		for _, c := range []Number{a.Add(b), b.Add(a), a.Sub(b), b.Sub(a),
+			a.Mul(b), b.Mul(a)} {
+			if _, ok := c.(*BigFloat); !ok {
+				t.Errorf("%T %s with %s", c, c, b)
+			}
+		}
+		if Hash(b) != Hash(NewBigFloat(b, 0)) {
+			t.Errorf("Hash(%s)", b)
+		}
+	}
+	if Hash(a) != Hash(Float64(2.5)) {
+		t.Errorf("Hash(%s)", a)
+	}
+	if n := AsNumber(big.NewFloat(1.5)); n.Cmp(Float64(1.5)) != 0 {
+		t.Errorf("AsNumber(big.NewFloat(1.5)) = %s", n)
+	}
+	x := NewBigFloat(Float64(0.1), 200)
+	if q := x.QuantizeTo(Int32(1)); q.String() != "0.0" {
+		t.Errorf("QuantizeTo = %s", q)
+	}
+	if s := FormatGrouped(NewBigFloat(Int64(1234567), 0), ','); s != "1,234,567.0" {
+		t.Errorf("FormatGrouped = %s", s)
+	}
+}
+
+func TestBigFloatFMA(t *testing.T) {
+	// (1 + 2**-60)**2 - 1 = 2**-59 + 2**-120 needs only one rounding.
+	x := NewBigFloat(Float64(1), 64).Add(Float64(math.Ldexp(1, -60)))
+	r := FMA(x, x, Int32(-1))
+	want := NewBigFloat(Float64(math.Ldexp(1, -59)), 64).Add(
+		Float64(math.Ldexp(1, -120)))
+	if r.Cmp(want) != 0 {
+		t.Errorf("FMA = %s", r)
+	}
+	if r = x.Mul(x).Add(Int32(-1)); r.Cmp(want) == 0 {
+		t.Errorf("Mul and Add = %s", r)
+	}
+}
@@ -0,0 +1,205 @@
+package goarith
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// Type tags put at the head of the binary encoding of each type.
+const (
+	tagInt32 byte = iota + 1
+	tagInt64
+	tagFloat64
+	tagBigInt
+	tagBigFloat
+	tagDecimal
+)
+
+// errBinary is reported by UnmarshalBinaryNumber for malformed data.
+var errBinary = errors.New("goarith: invalid binary encoding of Number")
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+// It encodes a into 5 bytes: a type tag and a in big endian.
+func (a Int32) MarshalBinary() ([]byte, error) {
+	return binary.BigEndian.AppendUint32([]byte{tagInt32}, uint32(a)), nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+// It encodes a into 9 bytes: a type tag and a in big endian.
+func (a Int64) MarshalBinary() ([]byte, error) {
+	return binary.BigEndian.AppendUint64([]byte{tagInt64}, uint64(a)), nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+// It encodes a into 9 bytes: a type tag and the IEEE 754 bits of a in
+// big endian.  The bits of NaN are kept as they are.
+func (a Float64) MarshalBinary() ([]byte, error) {
+	return binary.BigEndian.AppendUint64([]byte{tagFloat64}, a.Bits()), nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+// It encodes a into a type tag, a sign byte (1 if a < 0, 0 otherwise),
+// the length of |a| in bytes as an unsigned varint and |a| in big endian.
+func (a *BigInt) MarshalBinary() ([]byte, error) {
+	x := (*big.Int)(a)
+	abs := x.Bytes()
+	buf := []byte{tagBigInt, 0}
+	if x.Sign() < 0 {
+		buf[1] = 1
+	}
+	buf = binary.AppendUvarint(buf, uint64(len(abs)))
+	return append(buf, abs...), nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+// It encodes a into a type tag and the result of big.Float.GobEncode,
+// which keeps the precision and rounding mode of a.
+func (a *BigFloat) MarshalBinary() ([]byte, error) {
+	b, err := (*big.Float)(a).GobEncode()
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{tagBigFloat}, b...), nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+// It encodes a into a type tag, the scale of a in 4 bytes in big endian
+// and the coefficient of a in the same form as *BigInt without its tag.
+func (a *Decimal) MarshalBinary() ([]byte, error) {
+	coef, _ := (*BigInt)(a.coef).MarshalBinary()
+	buf := binary.BigEndian.AppendUint32([]byte{tagDecimal}, uint32(a.scale))
+	return append(buf, coef[1:]...), nil
+}
+
+// UnmarshalBinaryNumber decodes data encoded by MarshalBinary of any of
+// Int32, Int64, Float64, *BigInt, *BigFloat and *Decimal.  The result
+// has the same type and value as the encoded one; it is not reduced.
+func UnmarshalBinaryNumber(data []byte) (Number, error) {
+	if len(data) == 0 {
+		return nil, errBinary
+	}
+	tag, b := data[0], data[1:]
+	switch tag {
+	case tagInt32:
+		if len(b) == 4 {
+			return Int32(binary.BigEndian.Uint32(b)), nil
+		}
+	case tagInt64:
+		if len(b) == 8 {
+			return Int64(binary.BigEndian.Uint64(b)), nil
+		}
+	case tagFloat64:
+		if len(b) == 8 {
+			return Float64FromBits(binary.BigEndian.Uint64(b)), nil
+		}
+	case tagBigInt:
+		if len(b) == 0 || b[0] > 1 {
+			break
+		}
+		n, k := binary.Uvarint(b[1:])
+		if k <= 0 || n != uint64(len(b)-1-k) {
+			break
+		}
+		abs := b[1+k:]
+		x := new(big.Int).SetBytes(abs)
+		if b[0] == 1 {
+			x.Neg(x)
+		}
+		return (*BigInt)(x), nil
+	case tagBigFloat:
+		if len(b) == 0 {
+			break
+		}
+		x := new(big.Float)
+		if err := x.GobDecode(b); err != nil {
+			return nil, err
+		}
+		return (*BigFloat)(x), nil
+	case tagDecimal:
+		if len(b) < 4 {
+			break
+		}
+		coef, err := UnmarshalBinaryNumber(append([]byte{tagBigInt}, b[4:]...))
+		if err != nil {
+			return nil, err
+		}
+		scale := int32(binary.BigEndian.Uint32(b))
+		return &Decimal{(*big.Int)(coef.(*BigInt)), scale}, nil
+	}
+	return nil, errBinary
+}
+
+// ToBytes encodes an integer n into size bytes in order, e.g.
+// binary.BigEndian.  A nonnegative n is encoded as an unsigned integer
+// and a negative n in two's complement representation, so that n fits
+// if -2**(8*size-1) <= n < 2**(8*size); e.g. both 255 and -128 fit in
+// one byte.  An integral Float64 or *BigFloat is encoded as the equal
+// integer.  It reports an error if n is not an integer, or ErrRange if n
+// does not fit.  It panics if size is negative.
+func ToBytes(n Number, order binary.ByteOrder, size int) ([]byte, error) {
+	var z *big.Int
+	if x, ok := n.(*BigInt); ok {
+		z = (*big.Int)(x)
+	} else if x, ok := fixedInt(n); ok {
+		z = big.NewInt(x)
+	} else if n.IsInteger() {
+		z = ToBigRat(n).Num()
+	} else {
+		return nil, fmt.Errorf("goarith: non-integer %s", n.String())
+	}
+	buf := make([]byte, size)
+	if z.Sign() >= 0 {
+		if z.BitLen() > 8*size {
+			return nil, ErrRange
+		}
+		z.FillBytes(buf)
+	} else {
+		m := new(big.Int).Not(z) // -z - 1, which is nonnegative
+		if m.BitLen() >= 8*size {
+			return nil, ErrRange
+		}
+		m.FillBytes(buf)
+		for i := range buf {
+			buf[i] = ^buf[i] // -z - 1 inverted is z in two's complement.
+		}
+	}
+	if isLittleEndian(order) {
+		for i, j := 0, len(buf)-1; i < j; i, j = i+1, j-1 {
+			buf[i], buf[j] = buf[j], buf[i]
+		}
+	}
+	return buf, nil
+}
+
+// isLittleEndian reports whether order puts the least significant byte
+// first.
+func isLittleEndian(order binary.ByteOrder) bool {
+	var b [2]byte
+	order.PutUint16(b[:], 1)
+	return b[0] == 1
+}
+
+// FromBytes decodes b in order, e.g. binary.BigEndian, into the
+// narrowest of Int32, Int64 and *BigInt.  If signed is true, it treats b
+// as an integer in two's complement representation; otherwise as an
+// unsigned integer.  It recovers n from ToBytes(n, order, size) only if
+// signed matches the encoding of n: if signed is false and
+// 0 <= n < 2**(8*size), or if signed is true and
+// -2**(8*size-1) <= n < 2**(8*size-1).  For example, 255 in one byte is
+// decoded into -1 if signed is true.  An empty b is decoded into 0.
+func FromBytes(b []byte, order binary.ByteOrder, signed bool) Number {
+	if isLittleEndian(order) {
+		r := make([]byte, len(b))
+		for i, c := range b {
+			r[len(b)-1-i] = c
+		}
+		b = r
+	}
+	z := new(big.Int).SetBytes(b)
+	if signed && len(b) > 0 && b[0]&0x80 != 0 {
+		z.Sub(z, new(big.Int).Lsh(bigOne, uint(8*len(b))))
+	}
+	return (*BigInt)(z).reduce()
+}
@@ -0,0 +1,192 @@
+package goarith
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func ExampleUnmarshalBinaryNumber() {
+	for _, a := range []Number{Int32(-2), Int64(1), Float64(0.5),
+		(*BigInt)(big.NewInt(-258)), NewBigFloat(Float64(1.25), 100)} {
+		data, _ := a.(interface{ MarshalBinary() ([]byte, error) }).MarshalBinary()
+		b, err := UnmarshalBinaryNumber(data)
+		fmt.Printf("% x -> %T %s %v\n", data, b, b, err)
+	}
+	// Output:
+	// 01 ff ff ff fe -> goarith.Int32 -2 <nil>
+	// 02 00 00 00 00 00 00 00 01 -> goarith.Int64 1 <nil>
+	// 03 3f e0 00 00 00 00 00 00 -> goarith.Float64 0.5 <nil>
+	// 04 01 02 01 02 -> *goarith.BigInt -258 <nil>
+	// 05 01 0a 00 00 00 64 00 00 00 01 a0 00 00 00 00 00 00 00 -> *goarith.BigFloat 1.25 <nil>
+}
+
+func TestMarshalBinaryRoundTrip(t *testing.T) {
+	x, _ := new(big.Int).SetString("-1"+strings.Repeat("0", 100), 10)
+	negNaN := Float64(math.Copysign(math.NaN(), -1))
+	for _, a := range []Number{Int32(math.MinInt32), Int64(math.MaxInt64),
+		Float64(math.NaN()), negNaN, Float64(math.Copysign(0, -1)),
+		Float64(math.Inf(1)), (*BigInt)(x), (*BigInt)(new(big.Int)),
+		NewBigFloat(Float64(-0.1), 200)} {
+		data, err := a.(interface{ MarshalBinary() ([]byte, error) }).MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := UnmarshalBinaryNumber(data)
+		if err != nil {
+			t.Errorf("%s: %v", a, err)
+			continue
+		}
+		if fmt.Sprintf("%T", a) != fmt.Sprintf("%T", b) || a.CmpTotal(b) != 0 {
+			t.Errorf("%T %s -> %T %s", a, a, b, b)
+		}
+		if f, ok := a.(Float64); ok && f.Bits() != b.(Float64).Bits() {
+			t.Errorf("%s: bits %x -> %x", a, f.Bits(), b.(Float64).Bits())
+		}
+		if bf, ok := a.(*BigFloat); ok &&
+			(*big.Float)(bf).Prec() != (*big.Float)(b.(*BigFloat)).Prec() {
+			t.Errorf("%s: precision is not kept", a)
+		}
+	}
+	for _, data := range [][]byte{nil, {0}, {tagInt32, 1}, {tagInt64},
+		{tagFloat64, 1, 2, 3}, {tagBigInt}, {tagBigInt, 2, 0},
+		{tagBigInt, 0, 2, 1}, {tagBigInt, 0, 0x80}, {tagBigFloat},
+		{tagBigFloat, 99}, bytes.Repeat([]byte{9}, 9)} {
+		if b, err := UnmarshalBinaryNumber(data); err == nil {
+			t.Errorf("% x -> %s", data, b)
+		}
+	}
+}
+
+func ExampleToBytes() {
+	b, err := ToBytes(Int64(0x0102030405060708), binary.BigEndian, 8)
+	fmt.Printf("% x %v\n", b, err)
+	b, err = ToBytes(Int64(0x0102030405060708), binary.LittleEndian, 8)
+	fmt.Printf("% x %v\n", b, err)
+	b, err = ToBytes(Int32(-2), binary.BigEndian, 4)
+	fmt.Printf("% x %v\n", b, err)
+	b, err = ToBytes(Int32(0x1234), binary.LittleEndian, 3)
+	fmt.Printf("% x %v\n", b, err)
+	_, err = ToBytes(Int32(256), binary.BigEndian, 1)
+	fmt.Println(err)
+	_, err = ToBytes(Float64(0.5), binary.BigEndian, 1)
+	fmt.Println(err)
+	// Output:
+	// 01 02 03 04 05 06 07 08 <nil>
+	// 08 07 06 05 04 03 02 01 <nil>
+	// ff ff ff fe <nil>
+	// 34 12 00 <nil>
+	// goarith: value out of range
+	// goarith: non-integer 0.5
+}
+
+func TestToBytes(t *testing.T) {
+	for _, c := range []struct {
+		n    Number
+		size int
+		ok   bool
+	}{
+		{Int32(255), 1, true},
+		{Int32(-128), 1, true},
+		{Int32(-129), 1, false},
+		{Int32(0), 0, true},
+		{Int32(-1), 0, false},
+		{Int64(math.MinInt64), 8, true},
+		{Int32(1).Lsh(64), 8, false},
+		{Int32(1).Lsh(64).Dec(), 8, true},
+		{Int32(-1).Lsh(127), 16, true},
+		{Float64(-1e20), 9, true},
+	} {
+		b, err := ToBytes(c.n, binary.BigEndian, c.size)
+		if !c.ok {
+			if !errors.Is(err, ErrRange) {
+				t.Errorf("ToBytes(%s, %d): err = %v", c.n, c.size, err)
+			}
+			continue
+		} else if err != nil || len(b) != c.size {
+			t.Errorf("ToBytes(%s, %d) = % x, %v", c.n, c.size, b, err)
+			continue
+		}
+		// Decode b as unsigned and as signed in two's complement.
+		z := new(big.Int).SetBytes(b)
+		if c.n.IsNegative() {
+			z.Sub(z, new(big.Int).Lsh(bigOne, uint(8*c.size)))
+		}
+		if z.Cmp(ToBigRat(c.n).Num()) != 0 {
+			t.Errorf("ToBytes(%s, %d) = % x", c.n, c.size, b)
+		}
+	}
+}
+
+func ExampleFromBytes() {
+	b := []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08}
+	fmt.Println(FromBytes(b, binary.BigEndian, true), FromBytes(b, binary.LittleEndian, true))
+	b = []byte{0xff, 0xfe}
+	fmt.Println(FromBytes(b, binary.BigEndian, true), FromBytes(b, binary.BigEndian, false),
+		FromBytes(b, binary.LittleEndian, true), FromBytes(nil, binary.BigEndian, true))
+	b = bytes.Repeat([]byte{0xff}, 32)
+	n := FromBytes(b, binary.BigEndian, false)
+	fmt.Printf("%T %s\n", n, n)
+	fmt.Println(FromBytes(b, binary.BigEndian, true))
+	// Output:
+	// 72623859790382856 578437695752307201
+	// -2 65534 -257 0
+	// *goarith.BigInt 115792089237316195423570985008687907853269984665640564039457584007913129639935
+	// -1
+}
+
+func TestFromBytesRoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		size := i%40 + 1
+		z := new(big.Int).Rand(r, new(big.Int).Lsh(bigOne, uint(8*size-1)))
+		if i%2 == 1 {
+			z.Neg(z)
+		}
+		n := (*BigInt)(z).reduce()
+		for _, order := range []binary.ByteOrder{binary.BigEndian, binary.LittleEndian} {
+			b, err := ToBytes(n, order, size)
+			if err != nil {
+				t.Fatalf("ToBytes(%s, %d): %v", n, size, err)
+			}
+			if m := FromBytes(b, order, true); !Identical(m, n) {
+				t.Errorf("FromBytes(ToBytes(%s, %d)) = %s", n, size, m)
+			}
+			if m := FromBytes(b, order, false); n.IsNegative() != (m.Cmp(n) != 0) {
+				t.Errorf("FromBytes(ToBytes(%s, %d), unsigned) = %s", n, size, m)
+			}
+		}
+	}
+}
+
+func TestFromBytesSignedness(t *testing.T) {
+	for _, c := range []struct {
+		n      Number
+		size   int
+		signed Number
+		uns    Number
+	}{
+		{Int32(255), 1, Int32(-1), Int32(255)},
+		{Int32(127), 1, Int32(127), Int32(127)},
+		{Int32(-128), 1, Int32(-128), Int32(128)},
+		{Int64(1<<63 - 1), 8, Int64(1<<63 - 1), Int64(1<<63 - 1)},
+		{(*BigInt)(new(big.Int).Lsh(bigOne, 63)), 8, Int64(-1 << 63), (*BigInt)(new(big.Int).Lsh(bigOne, 63))},
+	} {
+		b, err := ToBytes(c.n, binary.BigEndian, c.size)
+		if err != nil {
+			t.Fatalf("ToBytes(%s, %d): %v", c.n, c.size, err)
+		}
+		if m := FromBytes(b, binary.BigEndian, true); !Identical(m, c.signed) {
+			t.Errorf("FromBytes(ToBytes(%s, %d), signed) = %s; want %s", c.n, c.size, m, c.signed)
+		}
+		if m := FromBytes(b, binary.BigEndian, false); !Identical(m, c.uns) {
+			t.Errorf("FromBytes(ToBytes(%s, %d), unsigned) = %s; want %s", c.n, c.size, m, c.uns)
+		}
+	}
+}
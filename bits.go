@@ -0,0 +1,322 @@
+package goarith
+
+import (
+	"fmt"
+	"math/big"
+	"math/bits"
+)
+
+// fixedInt returns the int64 value of an Int32 or Int64 and true.
+// For the other types, it returns 0 and false.
+func fixedInt(n Number) (int64, bool) {
+	switch x := n.(type) {
+	case Int32:
+		return int64(x), true
+	case Int64:
+		return int64(x), true
+	}
+	return 0, false
+}
+
+// bitwise returns f(a, b) if both a and b are Int32 or Int64, or
+// g(a, b) on their big.Int values otherwise.  Bitwise operations are
+// undefined for Float64; bitwise panics if a or b is not an integer.
+func bitwise(a, b Number, name string,
+	f func(x, y int64) int64, g func(z, x, y *big.Int) *big.Int) Number {
+	if x, ok := fixedInt(a); ok {
+		if y, ok := fixedInt(b); ok {
+			return Int64(f(x, y)).reduce()
+		}
+	}
+	x, y := toBigInt(a), toBigInt(b)
+	if x == nil || y == nil {
+		panic(fmt.Sprintf("%s.%s(%s)", a.String(), name, b.String()))
+	}
+	return (*BigInt)(g(x, x, y)).reduce()
+}
+
+func and(x, y int64) int64    { return x & y }
+func or(x, y int64) int64     { return x | y }
+func xor(x, y int64) int64    { return x ^ y }
+func andNot(x, y int64) int64 { return x &^ y }
+
+// And methods
+
+// And returns the bitwise AND of a and b, where b must be an integer.
+// Negative values are treated as in two's complement representation.
+func (a Int32) And(b Number) Number {
+	return bitwise(a, b, "And", and, (*big.Int).And)
+}
+
+func (a Int64) And(b Number) Number {
+	return bitwise(a, b, "And", and, (*big.Int).And)
+}
+
+func (a *BigInt) And(b Number) Number {
+	return bitwise(a, b, "And", and, (*big.Int).And)
+}
+
+// Or methods
+
+// Or returns the bitwise OR of a and b, where b must be an integer.
+// Negative values are treated as in two's complement representation.
+func (a Int32) Or(b Number) Number {
+	return bitwise(a, b, "Or", or, (*big.Int).Or)
+}
+
+func (a Int64) Or(b Number) Number {
+	return bitwise(a, b, "Or", or, (*big.Int).Or)
+}
+
+func (a *BigInt) Or(b Number) Number {
+	return bitwise(a, b, "Or", or, (*big.Int).Or)
+}
+
+// Xor methods
+
+// Xor returns the bitwise XOR of a and b, where b must be an integer.
+// Negative values are treated as in two's complement representation.
+func (a Int32) Xor(b Number) Number {
+	return bitwise(a, b, "Xor", xor, (*big.Int).Xor)
+}
+
+func (a Int64) Xor(b Number) Number {
+	return bitwise(a, b, "Xor", xor, (*big.Int).Xor)
+}
+
+func (a *BigInt) Xor(b Number) Number {
+	return bitwise(a, b, "Xor", xor, (*big.Int).Xor)
+}
+
+// AndNot methods
+
+// AndNot returns the bitwise AND NOT of a and b, where b must be an
+// integer.  Negative values are treated as in two's complement
+// representation.
+func (a Int32) AndNot(b Number) Number {
+	return bitwise(a, b, "AndNot", andNot, (*big.Int).AndNot)
+}
+
+func (a Int64) AndNot(b Number) Number {
+	return bitwise(a, b, "AndNot", andNot, (*big.Int).AndNot)
+}
+
+func (a *BigInt) AndNot(b Number) Number {
+	return bitwise(a, b, "AndNot", andNot, (*big.Int).AndNot)
+}
+
+// Not methods
+
+// Not returns the bitwise NOT of a, i.e. -a - 1.
+func (a Int32) Not() Number {
+	return ^a
+}
+
+func (a Int64) Not() Number {
+	return (^a).reduce()
+}
+
+func (a *BigInt) Not() Number {
+	z := new(big.Int)
+	return (*BigInt)(z.Not((*big.Int)(a))).reduce()
+}
+
+// lsh returns x << n, promoting it to a BigInt if it overflows int64.
+func lsh(x int64, n uint) Number {
+	if n < 64 {
+		if y := x << n; y>>n == x {
+			return Int64(y).reduce()
+		}
+	}
+	z := big.NewInt(x)
+	return (*BigInt)(z.Lsh(z, n)).reduce()
+}
+
+// Lsh methods
+
+// Lsh returns a << n, promoting the result as needed.
+func (a Int32) Lsh(n uint) Number {
+	return lsh(int64(a), n)
+}
+
+func (a Int64) Lsh(n uint) Number {
+	return lsh(int64(a), n)
+}
+
+func (a *BigInt) Lsh(n uint) Number {
+	z := new(big.Int)
+	return (*BigInt)(z.Lsh((*big.Int)(a), n)).reduce()
+}
+
+// Rsh methods
+
+// Rsh returns a >> n with sign extension, i.e. a / 2**n rounded toward
+// negative infinity.
+func (a Int32) Rsh(n uint) Number {
+	return a >> n
+}
+
+func (a Int64) Rsh(n uint) Number {
+	return (a >> n).reduce()
+}
+
+func (a *BigInt) Rsh(n uint) Number {
+	z := new(big.Int)
+	return (*BigInt)(z.Rsh((*big.Int)(a), n)).reduce()
+}
+
+// bit returns the value of the i'th bit of x in two's complement
+// representation.  It panics if i is negative.
+func bit(x int64, i int) uint {
+	if i < 0 {
+		panic("negative bit index")
+	} else if i > 63 {
+		i = 63 // The sign bit extends infinitely.
+	}
+	return uint(x>>uint(i)) & 1
+}
+
+// setBit returns x with its i'th bit set to b, promoting the result to a
+// BigInt if it does not fit in int64.  It panics if i is negative or b
+// is neither 0 nor 1.
+func setBit(x int64, i int, b uint) Number {
+	if i < 0 {
+		panic("negative bit index")
+	} else if b > 1 {
+		panic("set bit is not 0 or 1")
+	}
+	if i < 63 {
+		return Int64(x&^(1<<uint(i)) | int64(b)<<uint(i)).reduce()
+	}
+	z := big.NewInt(x)
+	return (*BigInt)(z.SetBit(z, i, b)).reduce()
+}
+
+// Bit methods
+
+// Bit returns the value of the i'th bit of a as big.Int.Bit does.
+// Negative values are treated as in two's complement representation.
+func (a Int32) Bit(i int) uint {
+	return bit(int64(a), i)
+}
+
+// Bit returns the value of the i'th bit of a as big.Int.Bit does.
+// Negative values are treated as in two's complement representation.
+func (a Int64) Bit(i int) uint {
+	return bit(int64(a), i)
+}
+
+// Bit returns the value of the i'th bit of a as big.Int.Bit does.
+// Negative values are treated as in two's complement representation.
+func (a *BigInt) Bit(i int) uint {
+	return (*big.Int)(a).Bit(i)
+}
+
+// SetBit methods
+
+// SetBit returns a with its i'th bit set to b (0 or 1) as big.Int.SetBit
+// does, promoting the result as needed; e.g. Int32(0).SetBit(100, 1)
+// returns a *BigInt.
+func (a Int32) SetBit(i int, b uint) Number {
+	return setBit(int64(a), i, b)
+}
+
+// SetBit returns a with its i'th bit set to b (0 or 1) as big.Int.SetBit
+// does, promoting the result as needed.
+func (a Int64) SetBit(i int, b uint) Number {
+	return setBit(int64(a), i, b)
+}
+
+// SetBit returns a with its i'th bit set to b (0 or 1) as big.Int.SetBit
+// does.
+func (a *BigInt) SetBit(i int, b uint) Number {
+	z := new(big.Int)
+	return (*BigInt)(z.SetBit((*big.Int)(a), i, b)).reduce()
+}
+
+// BitLen returns the length of the absolute value of a in bits.
+// The bit length of 0 is 0.
+func (a *BigInt) BitLen() int {
+	return (*big.Int)(a).BitLen()
+}
+
+// Log2Floor returns floor(log2(a)) exactly, i.e. a.BitLen() - 1, and
+// whether a is an exact power of two.  It panics if a is not positive.
+func (a *BigInt) Log2Floor() (int, bool) {
+	x := (*big.Int)(a)
+	if x.Sign() <= 0 {
+		panic(fmt.Sprintf("%s.Log2Floor()", a.String()))
+	}
+	n := x.BitLen() - 1
+	return n, x.TrailingZeroBits() == uint(n)
+}
+
+// BitLen returns the length of the absolute value of n in bits, e.g. 8
+// for Int64(255).  An integral Float64 or *BigFloat is measured as the
+// equal integer.  It panics if n is not an integer.
+func BitLen(n Number) int {
+	if x, ok := fixedInt(n); ok {
+		return bits.Len64(absInt64(x))
+	} else if x, ok := n.(*BigInt); ok {
+		return x.BitLen()
+	} else if !n.IsInteger() {
+		panic(fmt.Sprintf("BitLen(%s)", n.String()))
+	}
+	return ToBigRat(n).Num().BitLen()
+}
+
+// TrailingZeroBits returns the number of consecutive least significant
+// zero bits of the absolute value of n, i.e. the largest k such that 2**k
+// divides n; e.g. 3 for Int64(40).  It returns 0 for zero as
+// big.Int.TrailingZeroBits does.  An integral Float64 or *BigFloat is
+// measured as the equal integer.  It panics if n is not an integer.
+func TrailingZeroBits(n Number) int {
+	if x, ok := fixedInt(n); ok {
+		if x == 0 {
+			return 0
+		}
+		return bits.TrailingZeros64(uint64(x))
+	} else if x, ok := n.(*BigInt); ok {
+		return int((*big.Int)(x).TrailingZeroBits())
+	} else if !n.IsInteger() {
+		panic(fmt.Sprintf("TrailingZeroBits(%s)", n.String()))
+	}
+	return int(ToBigRat(n).Num().TrailingZeroBits())
+}
+
+// PopCount returns the number of one bits in the absolute value of n,
+// e.g. 8 for Int64(255) and also for Int64(-255); a negative value is
+// not counted in two's complement representation.  An integral Float64
+// or *BigFloat is counted as the equal integer.  It panics if n is not
+// an integer.
+func PopCount(n Number) int {
+	var z *big.Int
+	if x, ok := fixedInt(n); ok {
+		return bits.OnesCount64(absInt64(x))
+	} else if x, ok := n.(*BigInt); ok {
+		z = (*big.Int)(x)
+	} else if !n.IsInteger() {
+		panic(fmt.Sprintf("PopCount(%s)", n.String()))
+	} else {
+		z = ToBigRat(n).Num()
+	}
+	c := 0
+	for _, w := range z.Bits() {
+		c += bits.OnesCount(uint(w))
+	}
+	return c
+}
+
+// IsPowerOfTwo reports whether n is a positive integer which is an exact
+// power of two, e.g. 1, 2 and 1024.  An integral Float64 or *BigFloat is
+// tested as the equal integer.  It returns false for zero, negative
+// numbers and non-integers.
+func IsPowerOfTwo(n Number) bool {
+	if x, ok := fixedInt(n); ok {
+		return x > 0 && x&(x-1) == 0
+	} else if !n.IsInteger() {
+		return false
+	}
+	z := ToBigRat(n).Num()
+	return z.Sign() > 0 && z.TrailingZeroBits() == uint(z.BitLen()-1)
+}
@@ -0,0 +1,264 @@
+package goarith
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+func ExampleInt64_And() {
+	x, _ := new(big.Int).SetString("ffffffffffffffffffff", 16)
+	a := Int64(0x0f0f0f0f0f).And((*BigInt)(x))
+	fmt.Printf("%T %s\n", a, a.String())
+	a = Int32(-1).And(Int64(1 << 40))
+	fmt.Printf("%T %s\n", a, a.String())
+	// Output:
+	// goarith.Int64 64677154575
+	// goarith.Int64 1099511627776
+}
+
+func ExampleBigInt_Xor() {
+	x, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	ys := []Number{Int32(-12345), Int64(1 << 50), (*BigInt)(x)}
+	for _, y := range ys {
+		for _, z := range ys {
+			r := y.(interface{ Xor(Number) Number }).Xor(z)
+			r = r.(interface{ Xor(Number) Number }).Xor(z)
+			fmt.Print(" ", r.Cmp(y) == 0)
+		}
+	}
+	fmt.Println()
+	a := (*BigInt)(x).Xor((*BigInt)(x))
+	fmt.Printf("%T %s\n", a, a.String())
+	// Output:
+	//  true true true true true true true true true
+	// goarith.Int32 0
+}
+
+func ExampleInt32_Not() {
+	a := Int32(0).Not()
+	fmt.Printf("%T %s\n", a, a.String())
+	x, _ := new(big.Int).SetString("-100000000000000000000", 10)
+	a = (*BigInt)(x).Not()
+	fmt.Printf("%T %s\n", a, a.String())
+	a = Int64(6).Or(Int32(9)).(Int32).AndNot(Int32(3))
+	fmt.Printf("%T %s\n", a, a.String())
+	// Output:
+	// goarith.Int32 -1
+	// *goarith.BigInt 99999999999999999999
+	// goarith.Int32 12
+}
+
+func ExampleInt64_Lsh() {
+	for _, n := range []uint{30, 31, 62, 63, 100} {
+		a := Int64(1).Lsh(n)
+		fmt.Printf("%T %s\n", a, a.String())
+	}
+	a := Int32(-1).Lsh(63)
+	fmt.Printf("%T %s\n", a, a.String())
+	a = Int32(-3).Lsh(63)
+	fmt.Printf("%T %s\n", a, a.String())
+	// Output:
+	// goarith.Int32 1073741824
+	// goarith.Int64 2147483648
+	// goarith.Int64 4611686018427387904
+	// *goarith.BigInt 9223372036854775808
+	// *goarith.BigInt 1267650600228229401496703205376
+	// goarith.Int64 -9223372036854775808
+	// *goarith.BigInt -27670116110564327424
+}
+
+func ExampleBigInt_Rsh() {
+	x := new(big.Int).Lsh(big.NewInt(-5), 100)
+	for _, n := range []uint{40, 98, 101, 200} {
+		a := (*BigInt)(x).Rsh(n)
+		fmt.Printf("%T %s\n", a, a.String())
+	}
+	fmt.Println(Int32(-7).Rsh(1).String(), Int64(-7).Rsh(100).String(),
+		Int64(7).Rsh(100).String())
+	// Output:
+	// goarith.Int64 -5764607523034234880
+	// goarith.Int32 -20
+	// goarith.Int32 -3
+	// goarith.Int32 -1
+	// -4 -1 0
+}
+
+func ExampleBitLen() {
+	fmt.Println(BitLen(Int32(0)), BitLen(Int64(255)), BitLen(Int64(256)),
+		BitLen(Int32(-255)), BitLen(Int64(math.MinInt64)), BitLen(Float64(1e20)))
+	// Output:
+	// 0 8 9 8 64 67
+}
+
+func TestBitLen(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		x := new(big.Int).Rand(rnd, new(big.Int).Lsh(bigOne, uint(rnd.Intn(300))))
+		if i%2 == 0 {
+			x.Neg(x)
+		}
+		if got, want := BitLen((*BigInt)(x).reduce()), x.BitLen(); got != want {
+			t.Errorf("BitLen(%s) = %d, want %d", x, got, want)
+		}
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("BitLen(0.5) did not panic")
+		}
+	}()
+	BitLen(Float64(0.5))
+}
+
+func TestIsPowerOfTwo(t *testing.T) {
+	p70 := (*BigInt)(new(big.Int).Lsh(bigOne, 70))
+	for _, c := range []struct {
+		n    Number
+		want bool
+	}{
+		{Int32(1), true},
+		{Int32(2), true},
+		{Int32(1024), true},
+		{Int32(math.MinInt32), false},
+		{Int64(1 << 62), true},
+		{Int64(math.MinInt64), false},
+		{p70, true},
+		{p70.Add(Int32(1)), false},
+		{p70.Add(p70), true},
+		{Int32(0).Sub(p70), false},
+		{Int32(0), false},
+		{Int32(3), false},
+		{Int32(-2), false},
+		{Int64(6), false},
+		{Float64(0.5), false},
+		{Float64(1024), true},
+		{Float64(1e300), false},
+		{Float64(math.Ldexp(1, 1000)), true},
+		{Float64(math.Inf(1)), false},
+		{Float64(math.NaN()), false},
+	} {
+		if got := IsPowerOfTwo(c.n); got != c.want {
+			t.Errorf("IsPowerOfTwo(%s) = %t", c.n, got)
+		}
+	}
+}
+
+func ExampleInt32_SetBit() {
+	a := Int32(0).SetBit(100, 1)
+	fmt.Printf("%T %s\n", a, a)
+	fmt.Println(a.(*BigInt).Bit(100), a.(*BigInt).Bit(99))
+	b := a.(*BigInt).SetBit(100, 0)
+	fmt.Printf("%T %s\n", b, b)
+	c := Int32(-1).SetBit(31, 0)
+	fmt.Printf("%T %s %d\n", c, c, Int32(-1).Bit(1000))
+	// Output:
+	// *goarith.BigInt 1267650600228229401496703205376
+	// 1 0
+	// goarith.Int32 0
+	// goarith.Int64 -2147483649 1
+}
+
+func TestBitSetBit(t *testing.T) {
+	for _, x := range []int64{0, 1, -1, 5, -6, math.MaxInt32, math.MinInt32,
+		math.MaxInt64, math.MinInt64, 1 << 62} {
+		z := big.NewInt(x)
+		a := Int64(x).reduce()
+		for i := 0; i < 130; i++ {
+			var bt uint
+			switch y := a.(type) {
+			case Int32:
+				bt = y.Bit(i)
+			case Int64:
+				bt = y.Bit(i)
+			}
+			if bt != z.Bit(i) {
+				t.Errorf("%d.Bit(%d) = %d", x, i, bt)
+			}
+			for b := uint(0); b <= 1; b++ {
+				want := (*BigInt)(new(big.Int).SetBit(z, i, b)).reduce()
+				var got Number
+				switch y := a.(type) {
+				case Int32:
+					got = y.SetBit(i, b)
+				case Int64:
+					got = y.SetBit(i, b)
+				}
+				if fmt.Sprintf("%T %s", got, got) != fmt.Sprintf("%T %s", want, want) {
+					t.Errorf("%d.SetBit(%d, %d) = %T %s, want %T %s",
+						x, i, b, got, got, want, want)
+				}
+			}
+		}
+	}
+}
+
+func ExampleTrailingZeroBits() {
+	fmt.Println(TrailingZeroBits(Int64(40)), TrailingZeroBits(Int32(-8)),
+		TrailingZeroBits(Int32(0)), TrailingZeroBits(Int64(math.MinInt64)),
+		TrailingZeroBits(Int32(1).Lsh(200)), TrailingZeroBits(Float64(96)))
+	// Output:
+	// 3 3 0 63 200 5
+}
+
+func TestTrailingZeroBits(t *testing.T) {
+	for i := 0; i < 1000; i++ {
+		z := new(big.Int).Rand(rand.New(rand.NewSource(int64(i))),
+			new(big.Int).Lsh(bigOne, uint(i%130+1)))
+		z.Lsh(z, uint(i%70))
+		if i%2 == 1 {
+			z.Neg(z)
+		}
+		a := (*BigInt)(z).reduce()
+		if got := TrailingZeroBits(a); got != int(z.TrailingZeroBits()) {
+			t.Errorf("TrailingZeroBits(%s) = %d", a, got)
+		}
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("TrailingZeroBits(0.5) did not panic")
+		}
+	}()
+	TrailingZeroBits(Float64(0.5))
+}
+
+func ExamplePopCount() {
+	x, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	fmt.Println(PopCount(Int64(0xFF)), PopCount(Int32(-0xFF)), PopCount(Int32(0)),
+		PopCount(Int64(math.MinInt64)), PopCount((*BigInt)(x)),
+		PopCount(Int32(-1).Lsh(200).Inc()), PopCount(Float64(7)))
+	// Output:
+	// 8 8 0 1 54 200 3
+}
+
+func TestPopCount(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		z := new(big.Int).Rand(r, new(big.Int).Lsh(bigOne, uint(i%300+1)))
+		if i%2 == 1 {
+			z.Neg(z)
+		}
+		want := 0
+		for j := 0; j < z.BitLen(); j++ {
+			want += int(new(big.Int).Abs(z).Bit(j))
+		}
+		a := (*BigInt)(z).reduce()
+		if got := PopCount(a); got != want {
+			t.Errorf("PopCount(%s) = %d, want %d", a, got, want)
+		}
+	}
+}
+
+func ExampleBigInt_Log2Floor() {
+	p := new(big.Int).Lsh(bigOne, 200)
+	fmt.Println((*BigInt)(p).Log2Floor())
+	fmt.Println((*BigInt)(new(big.Int).Sub(p, bigOne)).Log2Floor())
+	fmt.Println((*BigInt)(new(big.Int).Add(p, bigOne)).Log2Floor())
+	fmt.Println((*BigInt)(big.NewInt(1)).Log2Floor())
+	// Output:
+	// 200 true
+	// 199 false
+	// 200 false
+	// 0 true
+}
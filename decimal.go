@@ -0,0 +1,564 @@
+package goarith
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// *Decimal implements Number as an exact decimal number, i.e. an integer
+// coefficient scaled by a power of ten: coef * 10**-scale.  For example,
+// "0.10" is the coefficient 10 with the scale 2.
+//
+// Add, Sub and Mul of a *Decimal and an integer or another *Decimal are
+// exact and result in a *Decimal: the scale of a sum or a difference is
+// the larger scale of the operands and that of a product is the sum of
+// their scales.  An integer operand has the scale 0.
+// An operation of a *Decimal and a Float64 converts the *Decimal into
+// the nearest float64 and results in a Float64, which may lose
+// precision.  An operation of a *Decimal and a *BigFloat results in a
+// *BigFloat.  Cmp, QuoRem and the other comparisons and divisions are
+// exact as long as no operand is a Float64.
+// A *Decimal is immutable and never reduced to an integer.
+// The zero value of Decimal is not a valid number; use NewDecimal or
+// ParseDecimal to make a *Decimal.
+type Decimal struct {
+	coef  *big.Int
+	scale int32
+}
+
+// NewDecimal returns a new *Decimal of the value coef * 10**-scale,
+// e.g. NewDecimal(big.NewInt(10), 2) for 0.10.  It copies coef.
+func NewDecimal(coef *big.Int, scale int32) *Decimal {
+	return &Decimal{new(big.Int).Set(coef), scale}
+}
+
+// ParseDecimal parses s as a decimal number with an optional sign, an
+// optional fractional part and an optional exponent, e.g. "0.10",
+// "-12.345" or "1.5e-3", into a *Decimal exactly.  The scale of the
+// result is the number of the fractional digits minus the exponent; e.g.
+// "0.10" has the scale 2 and "1.5e-3" has the scale 4.
+// The exponent must be within ±maxDecimalExp, so that a short string
+// cannot make a huge *Decimal such as "1e50000000"; otherwise
+// ParseDecimal reports strconv.ErrRange.
+func ParseDecimal(s string) (*Decimal, error) {
+	t := s
+	var exp int64
+	if i := strings.IndexAny(t, "eE"); i >= 0 {
+		e, err := strconv.ParseInt(t[i+1:], 10, 32)
+		if err != nil {
+			return nil, parseError("ParseDecimal", s, err.(*strconv.NumError).Err)
+		} else if e < -maxDecimalExp || maxDecimalExp < e {
+			return nil, parseError("ParseDecimal", s, strconv.ErrRange)
+		}
+		t, exp = t[:i], e
+	}
+	sign := ""
+	if t != "" && (t[0] == '+' || t[0] == '-') {
+		sign, t = t[:1], t[1:]
+	}
+	var frac string
+	if i := strings.IndexByte(t, '.'); i >= 0 {
+		t, frac = t[:i], t[i+1:]
+	}
+	digits := t + frac
+	if !isDecimalInteger(digits) || !isDigit(digits[0]) {
+		return nil, parseError("ParseDecimal", s, strconv.ErrSyntax)
+	}
+	scale := int64(len(frac)) - exp
+	if scale < math.MinInt32 || math.MaxInt32 < scale {
+		return nil, parseError("ParseDecimal", s, strconv.ErrRange)
+	}
+	z, _ := new(big.Int).SetString(sign+digits, 10)
+	return &Decimal{z, int32(scale)}, nil
+}
+
+// maxDecimalExp is the largest magnitude of the exponent which
+// ParseDecimal accepts.
+const maxDecimalExp = 100000
+
+// pow10 returns 10**n as a new big.Int, where n >= 0.
+func pow10(n int64) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(n), nil)
+}
+
+// toScale returns the int32 value of a scale s computed in int64.
+// It panics if s overflows int32.
+func toScale(s int64) int32 {
+	if s < math.MinInt32 || math.MaxInt32 < s {
+		panic(fmt.Sprintf("goarith: Decimal scale %d overflows int32", s))
+	}
+	return int32(s)
+}
+
+// toDecimal returns n as a *Decimal and true if n is an integer or a
+// *Decimal.  For the other types, it returns nil and false.
+func toDecimal(n Number) (*Decimal, bool) {
+	switch x := n.(type) {
+	case Int32:
+		return &Decimal{big.NewInt(int64(x)), 0}, true
+	case Int64:
+		return &Decimal{big.NewInt(int64(x)), 0}, true
+	case *BigInt:
+		return &Decimal{(*big.Int)(x), 0}, true
+	case *Decimal:
+		return x, true
+	}
+	return nil, false
+}
+
+// coefAt returns the coefficient of a at the scale s, which must not be
+// less than a.scale.  The result must not be modified.
+func (a *Decimal) coefAt(s int32) *big.Int {
+	if s == a.scale {
+		return a.coef
+	}
+	p := pow10(int64(s) - int64(a.scale))
+	return p.Mul(p, a.coef)
+}
+
+// align returns the coefficients of x and y at the larger of their
+// scales and the scale.  The coefficients must not be modified.
+func align(x, y *Decimal) (*big.Int, *big.Int, int32) {
+	s := x.scale
+	if y.scale > s {
+		s = y.scale
+	}
+	return x.coefAt(s), y.coefAt(s), s
+}
+
+func addDecimal(x, y *Decimal) *Decimal {
+	p, q, s := align(x, y)
+	return &Decimal{new(big.Int).Add(p, q), s}
+}
+
+func subDecimal(x, y *Decimal) *Decimal {
+	p, q, s := align(x, y)
+	return &Decimal{new(big.Int).Sub(p, q), s}
+}
+
+func mulDecimal(x, y *Decimal) *Decimal {
+	s := toScale(int64(x.scale) + int64(y.scale))
+	return &Decimal{new(big.Int).Mul(x.coef, y.coef), s}
+}
+
+// isDecimal reports whether a or b is a *Decimal.
+func isDecimal(a, b Number) bool {
+	_, da := a.(*Decimal)
+	_, db := b.(*Decimal)
+	return da || db
+}
+
+// decimalToFloat converts n into a *BigFloat if n is a *Decimal and
+// other is a *BigFloat, or into a Float64 if n is a *Decimal and other
+// is not.  It returns n as is if n is not a *Decimal.
+func decimalToFloat(n, other Number) Number {
+	x, ok := n.(*Decimal)
+	if !ok {
+		return n
+	} else if _, ok := other.(*BigFloat); ok {
+		return (*BigFloat)(toBigFloat(x))
+	}
+	return asFloat64(x)
+}
+
+// decimalOp returns f(a, b) if both a and b are integers or *Decimal,
+// where a or b is a *Decimal.  Otherwise it converts the *Decimal by
+// decimalToFloat and returns g(a, b).
+func decimalOp(a, b Number, f func(x, y *Decimal) *Decimal,
+	g func(a, b Number) Number) Number {
+	if x, ok := toDecimal(a); ok {
+		if y, ok := toDecimal(b); ok {
+			return f(x, y)
+		}
+	}
+	return g(decimalToFloat(a, b), decimalToFloat(b, a))
+}
+
+// exactDecimal returns n as an exact *Decimal and true if n is finite.
+// A finite Float64 or *BigFloat has an exact decimal representation
+// since its denominator is a power of two.
+func exactDecimal(n Number) (*Decimal, bool) {
+	if d, ok := toDecimal(n); ok {
+		return d, true
+	} else if r := ToBigRat(n); r != nil {
+		return ratDecimal(r)
+	}
+	return nil, false
+}
+
+// cmpAbsDecimals compares |x| and |y| exactly.  It estimates the
+// magnitudes from the bit lengths of the coefficients and the scales
+// first, so that it aligns the coefficients only if the magnitudes are
+// close and never computes 10**scale for a far larger or smaller scale.
+func cmpAbsDecimals(x, y *Decimal) int {
+	if x.coef.Sign() == 0 || y.coef.Sign() == 0 {
+		return x.coef.CmpAbs(y.coef)
+	}
+	// log10|x| lies in [(BitLen-1)*log10(2) - scale, BitLen*log10(2) - scale)
+	// and so does log10|y|.
+	lx := float64(x.coef.BitLen()-1)*math.Log10(2) - float64(x.scale)
+	ly := float64(y.coef.BitLen()-1)*math.Log10(2) - float64(y.scale)
+	if lx-ly > 1 {
+		return 1
+	} else if ly-lx > 1 {
+		return -1
+	}
+	p, q, _ := align(x, y)
+	return p.CmpAbs(q)
+}
+
+// cmpDecimal compares a and b exactly, where a or b is a *Decimal.
+// It returns 0 if a or b is NaN.
+func cmpDecimal(a, b Number) int {
+	x, ok := exactDecimal(a)
+	y, ok2 := exactDecimal(b)
+	if ok && ok2 {
+		if sx, sy := x.coef.Sign(), y.coef.Sign(); sx < sy {
+			return -1
+		} else if sx > sy {
+			return 1
+		} else if sx < 0 {
+			return -cmpAbsDecimals(x, y)
+		}
+		return cmpAbsDecimals(x, y)
+	}
+	// The other is infinite or NaN, and the finite *Decimal compares
+	// with it as 0 does.
+	return finiteAsZero(a, ok).Cmp(finiteAsZero(b, ok2))
+}
+
+// cmpAbsDecimal compares |a| and |b| exactly, where a or b is a
+// *Decimal.  It returns 0 if a or b is NaN.
+func cmpAbsDecimal(a, b Number) int {
+	x, ok := exactDecimal(a)
+	y, ok2 := exactDecimal(b)
+	if ok && ok2 {
+		return cmpAbsDecimals(x, y)
+	}
+	return finiteAsZero(a, ok).CmpAbs(finiteAsZero(b, ok2))
+}
+
+// finiteAsZero returns Float64(0) if finite is true and n as a Float64
+// otherwise.
+func finiteAsZero(n Number, finite bool) Float64 {
+	if finite {
+		return 0
+	}
+	return asFloat64(n)
+}
+
+// rquoDecimal returns a / b rounded to a Float64, where a or b is a
+// *Decimal.
+func rquoDecimal(a, b Number) Float64 {
+	if r := rquoRat(a, b); r != nil {
+		f, _ := r.Float64()
+		return Float64(f)
+	}
+	return asFloat64(a).RQuo(asFloat64(b))
+}
+
+// quoRemDecimal returns the truncated quotient and the remainder of a
+// and b, where a or b is a *Decimal.  If both a and b are integers or
+// *Decimal, the quotient will be an Int32, Int64 or BigInt and the
+// remainder will be a *Decimal, both computed exactly.  It panics if b
+// is zero then, as big.Int.QuoRem does.
+func quoRemDecimal(a, b Number) (Number, Number) {
+	if x, ok := toDecimal(a); ok {
+		if y, ok := toDecimal(b); ok {
+			p, q, s := align(x, y)
+			z, r := new(big.Int).QuoRem(p, q, new(big.Int))
+			return (*BigInt)(z).reduce(), &Decimal{r, s}
+		}
+	}
+	if bigFloatPrec(a, b) != 0 {
+		return quoRemBigFloat(a, b)
+	}
+	return asFloat64(a).quoRemFloat64(asFloat64(b))
+}
+
+// quoExactDecimal is the same as QuoExact, where a or b is a *Decimal.
+func quoExactDecimal(a, b Number) (Number, bool) {
+	if _, ok := toDecimal(a); ok {
+		if _, ok := toDecimal(b); ok {
+			q, r := quoRemDecimal(a, b)
+			if r.(*Decimal).coef.Sign() != 0 {
+				return nil, false
+			}
+			return q, true
+		}
+	}
+	if bigFloatPrec(a, b) != 0 {
+		return quoExactBigFloat(a, b)
+	}
+	return asFloat64(a).QuoExact(asFloat64(b))
+}
+
+// rat returns the value of a as a new big.Rat.
+func (a *Decimal) rat() *big.Rat {
+	if a.scale <= 0 {
+		return new(big.Rat).SetInt(a.coefAt(0))
+	}
+	return new(big.Rat).SetFrac(a.coef, pow10(int64(a.scale)))
+}
+
+// normalize returns the coefficient and the scale of a without the
+// trailing zeros of the coefficient, e.g. 5 and 1 for 0.50.  The
+// coefficient must not be modified.
+func (a *Decimal) normalize() (*big.Int, int64) {
+	c, s := a.coef, int64(a.scale)
+	if c.Sign() == 0 {
+		return c, 0
+	}
+	ten, q, r := big.NewInt(10), new(big.Int), new(big.Int)
+	for c.Bit(0) == 0 {
+		if q.QuoRem(c, ten, r); r.Sign() != 0 {
+			break
+		}
+		c, q = q, new(big.Int)
+		s--
+	}
+	return c, s
+}
+
+// ratDecimal returns r as an exact *Decimal and true if the denominator
+// of r has no prime factors other than 2 and 5.  Otherwise it returns
+// nil and false.
+func ratDecimal(r *big.Rat) (*Decimal, bool) {
+	d := r.Denom()
+	twos := int64(d.TrailingZeroBits())
+	q := new(big.Int).Rsh(d, uint(twos))
+	var fives int64
+	five, t, m := big.NewInt(5), new(big.Int), new(big.Int)
+	for {
+		if t.QuoRem(q, five, m); m.Sign() != 0 {
+			break
+		}
+		q, t = t, q
+		fives++
+	}
+	if q.Cmp(bigOne) != 0 {
+		return nil, false
+	}
+	scale := twos
+	if fives > scale {
+		scale = fives
+	}
+	z := new(big.Int).Mul(r.Num(), pow10(scale))
+	return &Decimal{z.Quo(z, d), toScale(scale)}, true
+}
+
+// reduceScale returns a as the narrowest integer if a has no fractional
+// digits, i.e. a.scale <= 0, and a as is otherwise.
+func (a *Decimal) reduceScale() Number {
+	if a.scale <= 0 {
+		return (*BigInt)(a.coefAt(0)).reduce()
+	}
+	return a
+}
+
+// roundTo returns a rounded to the scale s according to mode.
+// If s is not less than a.scale, it returns a with the coefficient
+// rescaled to s.
+func (a *Decimal) roundTo(s int32, mode RoundingMode) *Decimal {
+	if s >= a.scale {
+		return &Decimal{a.coefAt(s), s}
+	}
+	p := pow10(int64(a.scale) - int64(s))
+	return &Decimal{quoRound(a.coef, p, mode), s}
+}
+
+// String method
+
+// String returns a in the positional notation without exponent, keeping
+// the trailing zeros of the scale, e.g. "0.10" or "-1.250".
+func (a *Decimal) String() string {
+	if a.scale <= 0 {
+		return a.coefAt(0).String()
+	}
+	digits := new(big.Int).Abs(a.coef).String()
+	if n := int(a.scale) + 1 - len(digits); n > 0 {
+		digits = strings.Repeat("0", n) + digits
+	}
+	sign := ""
+	if a.coef.Sign() < 0 {
+		sign = "-"
+	}
+	i := len(digits) - int(a.scale)
+	return sign + digits[:i] + "." + digits[i:]
+}
+
+// Int methods
+
+func (a *Decimal) Int() (int, bool) {
+	i, exact := a.Int64()
+	j, exact2 := Int64(i).Int()
+	return j, exact && exact2
+}
+
+func (a *Decimal) Int64() (int64, bool) {
+	i, exact := (*BigInt)(a.roundTo(0, ToZero).coef).Int64()
+	return i, exact && a.IsInteger()
+}
+
+func (a *Decimal) Int32() (int32, bool) {
+	return toInt32(a.Trunc().Int64())
+}
+
+func (a *Decimal) Uint64() (uint64, bool) {
+	u, exact := (*BigInt)(a.roundTo(0, ToZero).coef).Uint64()
+	return u, exact && a.IsInteger()
+}
+
+// Arithmetic methods
+
+func (a *Decimal) Add(b Number) Number {
+	return decimalOp(a, b, addDecimal, Number.Add)
+}
+
+func (a *Decimal) Sub(b Number) Number {
+	return decimalOp(a, b, subDecimal, Number.Sub)
+}
+
+func (a *Decimal) Inc() Number {
+	return addDecimal(a, &Decimal{bigOne, 0})
+}
+
+func (a *Decimal) Dec() Number {
+	return subDecimal(a, &Decimal{bigOne, 0})
+}
+
+func (a *Decimal) Cmp(b Number) int {
+	return cmpDecimal(a, b)
+}
+
+func (a *Decimal) CmpErr(b Number) (int, error) {
+	return cmpErr(a, b)
+}
+
+func (a *Decimal) CmpAbs(b Number) int {
+	return cmpAbsDecimal(a, b)
+}
+
+func (a *Decimal) Mul(b Number) Number {
+	return decimalOp(a, b, mulDecimal, Number.Mul)
+}
+
+// Pow returns a**b.  If b is a nonnegative Int32 or Int64, the result is
+// an exact *Decimal of the scale a.scale * b.  Otherwise it is computed
+// as pow does for the other types.
+func (a *Decimal) Pow(b Number) Number {
+	if n, ok := fixedInt(b); ok && n >= 0 && n <= math.MaxInt32 {
+		s := int64(a.scale) * n
+		if math.MinInt32 <= s && s <= math.MaxInt32 {
+			z := new(big.Int).Exp(a.coef, big.NewInt(n), nil)
+			return &Decimal{z, int32(s)}
+		}
+	}
+	return pow(a, b)
+}
+
+func (a *Decimal) Log() Float64 {
+	return logNumber(a, math.Log, math.Ln2)
+}
+
+func (a *Decimal) Log2() Float64 {
+	return logNumber(a, math.Log2, 1)
+}
+
+func (a *Decimal) Log10() Float64 {
+	return logNumber(a, math.Log10, math.Log10(2))
+}
+
+func (a *Decimal) RQuo(b Number) Float64 {
+	return rquoDecimal(a, b)
+}
+
+func (a *Decimal) RQuoRat(b Number) *big.Rat {
+	return rquoRat(a, b)
+}
+
+func (a *Decimal) QuoRem(b Number) (Number, Number) {
+	return quoRemDecimal(a, b)
+}
+
+func (a *Decimal) FloorDivMod(b Number) (Number, Number) {
+	return floorDivMod(a, b)
+}
+
+func (a *Decimal) RQuoRound(b Number, mode RoundingMode) Number {
+	return quoRoundRat(a, b, mode)
+}
+
+func (a *Decimal) QuoExact(b Number) (Number, bool) {
+	return quoExactDecimal(a, b)
+}
+
+// Other methods
+
+func (a *Decimal) IsInteger() bool {
+	if a.scale <= 0 || a.coef.Sign() == 0 {
+		return true
+	} else if a.coef.BitLen() <= 3*int(a.scale) {
+		return false // 0 < |a.coef| < 10**a.scale
+	}
+	return new(big.Int).Rem(a.coef, pow10(int64(a.scale))).Sign() == 0
+}
+
+func (a *Decimal) IsOne() bool {
+	return a.scale >= 0 && a.coef.Cmp(pow10(int64(a.scale))) == 0
+}
+
+func (a *Decimal) IsInf(sign int) bool {
+	return false
+}
+
+func (a *Decimal) IsNaN() bool {
+	return false
+}
+
+func (a *Decimal) IsNegative() bool {
+	return a.coef.Sign() < 0
+}
+
+func (a *Decimal) IsPositive() bool {
+	return a.coef.Sign() > 0
+}
+
+func (a *Decimal) Signbit() bool {
+	return a.coef.Sign() < 0
+}
+
+func (a *Decimal) QuantizeTo(step Number) Number {
+	return quantize(a, step)
+}
+
+func (a *Decimal) Combine(b Number) uint64 {
+	return combineHash(a, b)
+}
+
+func (a *Decimal) Reduce() Number {
+	return a
+}
+
+func (a *Decimal) CmpTotal(b Number) int {
+	return cmpTotal(a, b)
+}
+
+func (a *Decimal) Trunc() Number {
+	return a.roundTo(0, ToZero)
+}
+
+func (a *Decimal) Floor() Number {
+	return a.roundTo(0, Down)
+}
+
+func (a *Decimal) Ceil() Number {
+	return a.roundTo(0, Up)
+}
+
+func (a *Decimal) Round() Number {
+	return a.roundTo(0, ToNearestAway)
+}
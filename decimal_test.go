@@ -0,0 +1,191 @@
+package goarith
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"testing"
+)
+
+func ExampleDecimal() {
+	tenth, _ := ParseDecimal("0.1")
+	var sum Number = Int32(0)
+	for i := 0; i < 10; i++ {
+		sum = sum.Add(tenth)
+	}
+	fmt.Println(sum, sum.Cmp(Int32(1)), sum.IsOne())
+	f := Float64(0)
+	for i := 0; i < 10; i++ {
+		f = f.Add(Float64(0.1)).(Float64)
+	}
+	fmt.Println(f, f.Cmp(Int32(1)))
+	// Output:
+	// 1.0 0 true
+	// 0.9999999999999999 -1
+}
+
+func ExampleParseDecimal() {
+	for _, s := range []string{"0.10", "-1.250", "1.5e-3", "+12e2", "7", ".5", "1.", "1e", "x"} {
+		d, err := ParseDecimal(s)
+		if err != nil {
+			fmt.Println(err)
+			continue
+		}
+		fmt.Println(d, d.scale)
+	}
+	// Output:
+	// 0.10 2
+	// -1.250 3
+	// 0.0015 4
+	// 1200 -2
+	// 7 0
+	// 0.5 1
+	// 1 0
+	// goarith.ParseDecimal: parsing "1e": invalid syntax
+	// goarith.ParseDecimal: parsing "x": invalid syntax
+}
+
+func ExampleDecimal_Add() {
+	a, _ := ParseDecimal("1.10")
+	b, _ := ParseDecimal("0.025")
+	x, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	fmt.Println(a.Add(b), a.Sub(b), a.Mul(b))
+	fmt.Println(a.Add(Int32(2)), Int64(2).Sub(a), (*BigInt)(x).Mul(a))
+	fmt.Printf("%T %s\n", a.Add(Float64(0.5)), a.Add(Float64(0.5)))
+	fmt.Printf("%T %s\n", a.Mul(NewBigFloat(Int32(2), 64)), a.Mul(NewBigFloat(Int32(2), 64)))
+	// Output:
+	// 1.125 1.075 0.02750
+	// 3.10 0.90 135802467913580246791358024679.00
+	// goarith.Float64 1.6
+	// *goarith.BigFloat 2.2
+}
+
+func ExampleDecimal_Cmp() {
+	a, _ := ParseDecimal("0.10")
+	b, _ := ParseDecimal("0.1")
+	c, _ := ParseDecimal("-0.5")
+	fmt.Println(a.Cmp(b), a.Cmp(c), c.CmpAbs(a), c.Cmp(Float64(-0.5)))
+	fmt.Println(Int32(0).Cmp(a), a.Cmp(Float64(0.1)), Identical(a, b))
+	// Output:
+	// 0 1 1 0
+	// -1 -1 true
+}
+
+func ExampleDecimal_QuoRem() {
+	a, _ := ParseDecimal("7.5")
+	b, _ := ParseDecimal("0.2")
+	q, r := a.QuoRem(b)
+	fmt.Println(q, r)
+	q, ok := a.QuoExact(Int32(3))
+	fmt.Println(q, ok, a.RQuo(Int32(2)))
+	fmt.Println(a.Round(), a.Floor(), Int32(0).Sub(a).Trunc(), a.QuantizeTo(b))
+	// Output:
+	// 37 0.1
+	// <nil> false 3.75
+	// 8 7 -7 7.6
+}
+
+func TestDecimalConsistency(t *testing.T) {
+	half, _ := ParseDecimal("0.50")
+	one, _ := ParseDecimal("1.00")
+	for _, c := range []struct {
+		d Number
+		n Number
+	}{
+		{half, Float64(0.5)},
+		{one, Int32(1)},
+		{one, Float64(1)},
+	} {
+		if c.d.Cmp(c.n) != 0 || c.n.Cmp(c.d) != 0 {
+			t.Errorf("%s and %s compare unequal", c.d, c.n)
+		}
+		if Hash(c.d) != Hash(c.n) {
+			t.Errorf("Hash(%s) != Hash(%s)", c.d, c.n)
+		}
+	}
+	for _, s := range []string{"0", "0.10", "-1.250", "1.5e-3", "123456789012345678901234.5"} {
+		d, _ := ParseDecimal(s)
+		b, err := d.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		n, err := UnmarshalBinaryNumber(b)
+		if err != nil || !Identical(d, n) || n.String() != d.String() {
+			t.Errorf("%s: UnmarshalBinaryNumber = %v, %v", s, n, err)
+		}
+	}
+	if z := Zero(half); z.String() != "0.00" {
+		t.Errorf("Zero(%s) = %s", half, z)
+	}
+	if n := AsNumber("0.10"); n == nil || n.String() != "0.10" {
+		t.Errorf(`AsNumber("0.10") = %v`, n)
+	}
+}
+
+func TestDecimalLargeScale(t *testing.T) {
+	for _, s := range []string{"1e50000000", "1e100001", "1.5e-100001"} {
+		if d, err := ParseDecimal(s); !errors.Is(err, strconv.ErrRange) {
+			t.Errorf("ParseDecimal(%q) = %v, %v", s, d, err)
+		}
+	}
+	if d, err := ParseDecimal("1e-100000"); err != nil || d.scale != 100000 {
+		t.Errorf(`ParseDecimal("1e-100000") = %v, %v`, d, err)
+	}
+	// These would take minutes if 10**scale were computed in full.
+	huge := NewDecimal(big.NewInt(1), -1<<30)
+	negHuge := NewDecimal(big.NewInt(-1), -1<<30)
+	tiny := NewDecimal(big.NewInt(1), 1<<30)
+	for _, c := range []struct {
+		a, b Number
+		want int
+	}{
+		{huge, Int32(1), 1},
+		{huge, Float64(math.MaxFloat64), 1},
+		{huge, Float64(math.Inf(1)), -1},
+		{negHuge, Float64(math.Inf(-1)), 1},
+		{negHuge, huge, -1},
+		{tiny, Int32(0), 1},
+		{tiny, Float64(math.SmallestNonzeroFloat64), -1},
+		{tiny, NewDecimal(big.NewInt(10), 1<<30+1), 0},
+		{tiny, NewDecimal(big.NewInt(2), 1<<30), -1},
+		{huge, NewDecimal(big.NewInt(1), -1<<30+1), 1},
+	} {
+		if got := c.a.Cmp(c.b); got != c.want {
+			t.Errorf("%s.Cmp(%s) = %d, want %d", Kind(c.a), c.b, got, c.want)
+		}
+		if got := c.b.Cmp(c.a); got != -c.want {
+			t.Errorf("%s.Cmp(%s) = %d, want %d", Kind(c.b), Kind(c.a), got, -c.want)
+		}
+	}
+	if c := negHuge.CmpAbs(huge); c != 0 {
+		t.Errorf("-huge.CmpAbs(huge) = %d", c)
+	}
+	if Hash(tiny) != Hash(NewDecimal(big.NewInt(10), 1<<30+1)) ||
+		Hash(tiny) == Hash(NewDecimal(big.NewInt(2), 1<<30)) {
+		t.Error("Hash(tiny) is wrong")
+	}
+	if tiny.IsInteger() {
+		t.Error("tiny.IsInteger() = true")
+	}
+}
+
+func TestAsNumberDecimalString(t *testing.T) {
+	for _, c := range []struct {
+		s, want string // want is "%T %s" of AsNumber(s).
+	}{
+		{"12", "goarith.Int32 12"},
+		{"-1e3", "goarith.Int32 -1000"},
+		{"1e10", "goarith.Int64 10000000000"},
+		{"12.0", "*goarith.Decimal 12.0"},
+		{"0.10", "*goarith.Decimal 0.10"},
+		{"1.5e1", "goarith.Int32 15"},
+		{"1.5e-1", "*goarith.Decimal 0.15"},
+	} {
+		n := AsNumber(c.s)
+		if got := fmt.Sprintf("%T %s", n, n); got != c.want {
+			t.Errorf("AsNumber(%q) = %s, want %s", c.s, got, c.want)
+		}
+	}
+}
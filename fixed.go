@@ -0,0 +1,211 @@
+package goarith
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"math/bits"
+)
+
+// ErrOverflow is returned by AddChecked, SubChecked and MulChecked when
+// the result does not fit in the width of the operands.
+var ErrOverflow = errors.New("goarith: integer overflow")
+
+// clampInt32 returns x clamped to the range of int32.
+func clampInt32(x int64) Int32 {
+	if x > math.MaxInt32 {
+		return math.MaxInt32
+	} else if x < math.MinInt32 {
+		return math.MinInt32
+	}
+	return Int32(x)
+}
+
+// saturate returns math.MinInt64 if neg, or math.MaxInt64 otherwise.
+func saturate(neg bool) Int64 {
+	if neg {
+		return math.MinInt64
+	}
+	return math.MaxInt64
+}
+
+// AddSat returns a + b clamped to [math.MinInt32, math.MaxInt32]
+// instead of being promoted.
+func (a Int32) AddSat(b Int32) Int32 {
+	return clampInt32(int64(a) + int64(b))
+}
+
+// SubSat returns a - b clamped to [math.MinInt32, math.MaxInt32]
+// instead of being promoted.
+func (a Int32) SubSat(b Int32) Int32 {
+	return clampInt32(int64(a) - int64(b))
+}
+
+// MulSat returns a * b clamped to [math.MinInt32, math.MaxInt32]
+// instead of being promoted.
+func (a Int32) MulSat(b Int32) Int32 {
+	return clampInt32(int64(a) * int64(b))
+}
+
+// checkedAdd returns a + b and true, or false if it overflows.
+func checkedAdd(a, b int64) (int64, bool) {
+	c := a + b
+	return c, (a >= 0) != (b >= 0) || (c >= 0) == (a >= 0)
+}
+
+// checkedSub returns a - b and true, or false if it overflows.
+func checkedSub(a, b int64) (int64, bool) {
+	c := a - b
+	return c, (a >= 0) == (b >= 0) || (c >= 0) == (a >= 0)
+}
+
+// checkedMul returns a * b and true, or false if it overflows.
+func checkedMul(a, b int64) (int64, bool) {
+	hi, lo := bits.Mul64(absInt64(a), absInt64(b))
+	if (a < 0) != (b < 0) {
+		return -int64(lo), hi == 0 && lo <= 1<<63 // -int64(1<<63) is math.MinInt64.
+	}
+	return int64(lo), hi == 0 && lo < 1<<63
+}
+
+// AddSat returns a + b clamped to [math.MinInt64, math.MaxInt64]
+// instead of being promoted.
+func (a Int64) AddSat(b Int64) Int64 {
+	if c, ok := checkedAdd(int64(a), int64(b)); ok {
+		return Int64(c)
+	}
+	return saturate(a < 0)
+}
+
+// SubSat returns a - b clamped to [math.MinInt64, math.MaxInt64]
+// instead of being promoted.
+func (a Int64) SubSat(b Int64) Int64 {
+	if c, ok := checkedSub(int64(a), int64(b)); ok {
+		return Int64(c)
+	}
+	return saturate(a < 0)
+}
+
+// MulSat returns a * b clamped to [math.MinInt64, math.MaxInt64]
+// instead of being promoted.
+func (a Int64) MulSat(b Int64) Int64 {
+	if c, ok := checkedMul(int64(a), int64(b)); ok {
+		return Int64(c)
+	}
+	return saturate((a < 0) != (b < 0))
+}
+
+// AddWrap returns a + b wrapped around within int32 as Go's int32
+// arithmetic does, instead of being promoted.
+func (a Int32) AddWrap(b Int32) Int32 {
+	return a + b
+}
+
+// SubWrap returns a - b wrapped around within int32 as Go's int32
+// arithmetic does, instead of being promoted.
+func (a Int32) SubWrap(b Int32) Int32 {
+	return a - b
+}
+
+// MulWrap returns a * b wrapped around within int32 as Go's int32
+// arithmetic does, instead of being promoted.
+func (a Int32) MulWrap(b Int32) Int32 {
+	return a * b
+}
+
+// AddWrap returns a + b wrapped around within int64 as Go's int64
+// arithmetic does, instead of being promoted.
+func (a Int64) AddWrap(b Int64) Int64 {
+	return a + b
+}
+
+// SubWrap returns a - b wrapped around within int64 as Go's int64
+// arithmetic does, instead of being promoted.
+func (a Int64) SubWrap(b Int64) Int64 {
+	return a - b
+}
+
+// MulWrap returns a * b wrapped around within int64 as Go's int64
+// arithmetic does, instead of being promoted.
+func (a Int64) MulWrap(b Int64) Int64 {
+	return a * b
+}
+
+// checked returns the result of f on the int64 values of a and b and
+// nil, where a is an Int32 or Int64.  If b is an Int32 or Int64 too, the
+// result must fit in the wider of a and b; otherwise checked returns nil
+// and ErrOverflow.  If b is neither, it returns g(b) and nil.
+func checked(a, b Number, f func(x, y int64) (int64, bool),
+	g func(b Number) Number) (Number, error) {
+	x, _ := fixedInt(a)
+	y, ok := fixedInt(b)
+	if !ok {
+		return g(b), nil
+	}
+	c, ok := f(x, y)
+	if !ok {
+		return nil, ErrOverflow
+	}
+	_, a64 := a.(Int64)
+	_, b64 := b.(Int64)
+	if a64 || b64 {
+		return Int64(c).reduce(), nil
+	} else if c < math.MinInt32 || math.MaxInt32 < c {
+		return nil, ErrOverflow
+	}
+	return Int32(c), nil
+}
+
+// AddChecked returns a + b.  If b is an Int32 and the sum does not fit
+// in int32, or b is an Int64 and the sum does not fit in int64, it
+// returns ErrOverflow instead of promoting the sum.  Otherwise it
+// returns the same result as Add.
+func (a Int32) AddChecked(b Number) (Number, error) {
+	return checked(a, b, checkedAdd, a.Add)
+}
+
+// SubChecked is the same as AddChecked except that it returns a - b.
+func (a Int32) SubChecked(b Number) (Number, error) {
+	return checked(a, b, checkedSub, a.Sub)
+}
+
+// MulChecked is the same as AddChecked except that it returns a * b.
+func (a Int32) MulChecked(b Number) (Number, error) {
+	return checked(a, b, checkedMul, a.Mul)
+}
+
+// AddChecked returns a + b.  If b is an Int32 or Int64 and the sum does
+// not fit in int64, it returns ErrOverflow instead of promoting the sum.
+// Otherwise it returns the same result as Add.
+func (a Int64) AddChecked(b Number) (Number, error) {
+	return checked(a, b, checkedAdd, a.Add)
+}
+
+// SubChecked is the same as AddChecked except that it returns a - b.
+func (a Int64) SubChecked(b Number) (Number, error) {
+	return checked(a, b, checkedSub, a.Sub)
+}
+
+// MulChecked is the same as AddChecked except that it returns a * b.
+func (a Int64) MulChecked(b Number) (Number, error) {
+	return checked(a, b, checkedMul, a.Mul)
+}
+
+// MulDiv returns a * mul / div truncated toward zero as Go's integer
+// division, computed exactly without intermediate overflow.  Only when
+// a * mul overflows int64, it computes the result in big.Int, and then
+// it reduces the result to the narrowest type, e.g. an Int64 if the
+// quotient fits in int64 again.  It panics if div is zero.
+func (a Int64) MulDiv(mul, div Int64) Number {
+	if div == 0 {
+		panic(fmt.Sprintf("%s.MulDiv(%s, %s)", a.String(), mul.String(), div.String()))
+	}
+	if p, ok := checkedMul(int64(a), int64(mul)); ok {
+		if p != math.MinInt64 || div != -1 {
+			return (Int64(p) / div).reduce()
+		}
+	}
+	z := new(big.Int).Mul(big.NewInt(int64(a)), big.NewInt(int64(mul)))
+	return (*BigInt)(z.Quo(z, big.NewInt(int64(div)))).reduce()
+}
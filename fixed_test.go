@@ -0,0 +1,219 @@
+package goarith
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func ExampleInt32_AddSat() {
+	fmt.Println(Int32(math.MaxInt32).AddSat(Int32(1)))
+	fmt.Println(Int32(math.MinInt32).SubSat(Int32(1)))
+	fmt.Println(Int32(1 << 20).MulSat(Int32(-1 << 20)))
+	fmt.Println(Int32(100).AddSat(Int32(-300)))
+	// Output:
+	// 2147483647
+	// -2147483648
+	// -2147483648
+	// -200
+}
+
+func TestSaturation(t *testing.T) {
+	const max32, min32 = math.MaxInt32, math.MinInt32
+	for _, c := range []struct {
+		got, want Int32
+	}{
+		{Int32(max32).AddSat(1), max32},
+		{Int32(max32 - 1).AddSat(1), max32},
+		{Int32(min32).AddSat(-1), min32},
+		{Int32(min32).AddSat(max32), -1},
+		{Int32(max32).SubSat(-1), max32},
+		{Int32(min32).SubSat(1), min32},
+		{Int32(-1).SubSat(max32), min32},
+		{Int32(0).SubSat(min32), max32},
+		{Int32(min32).MulSat(-1), max32},
+		{Int32(min32).MulSat(1), min32},
+		{Int32(65536).MulSat(32768), max32},
+		{Int32(65536).MulSat(-32768), min32},
+		{Int32(65535).MulSat(32768), 2147450880},
+	} {
+		if c.got != c.want {
+			t.Errorf("got %d, want %d", c.got, c.want)
+		}
+	}
+	const max64, min64 = math.MaxInt64, math.MinInt64
+	for _, c := range []struct {
+		got, want Int64
+	}{
+		{Int64(max64).AddSat(1), max64},
+		{Int64(max64 - 1).AddSat(1), max64},
+		{Int64(min64).AddSat(-1), min64},
+		{Int64(min64).AddSat(max64), -1},
+		{Int64(max64).AddSat(max64), max64},
+		{Int64(min64).AddSat(min64), min64},
+		{Int64(max64).SubSat(-1), max64},
+		{Int64(min64).SubSat(1), min64},
+		{Int64(-1).SubSat(max64), min64},
+		{Int64(0).SubSat(min64), max64},
+		{Int64(-1).SubSat(min64), max64},
+		{Int64(min64).MulSat(-1), max64},
+		{Int64(min64).MulSat(1), min64},
+		{Int64(1 << 32).MulSat(-1 << 31), min64},
+		{Int64(1 << 32).MulSat(1 << 31), max64},
+		{Int64(1 << 32).MulSat(-1 << 32), min64},
+		{Int64(3037000499).MulSat(3037000499), 9223372030926249001},
+		{Int64(-5).MulSat(0), 0},
+	} {
+		if c.got != c.want {
+			t.Errorf("got %d, want %d", c.got, c.want)
+		}
+	}
+}
+
+func ExampleInt32_AddWrap() {
+	fmt.Println(Int32(math.MaxInt32).AddWrap(Int32(1)))
+	fmt.Println(Int32(math.MinInt32).SubWrap(Int32(1)))
+	fmt.Println(Int32(0x10001).MulWrap(Int32(0x10001)))
+	fmt.Println(Int64(math.MaxInt64).AddWrap(Int64(1)))
+	fmt.Println(Int64(math.MinInt64).MulWrap(Int64(-1)))
+	// Output:
+	// -2147483648
+	// 2147483647
+	// 131073
+	// -9223372036854775808
+	// -9223372036854775808
+}
+
+func TestWrapMatchesModularArithmetic(t *testing.T) {
+	// The wrapped result is congruent to the exact one modulo 2**32 or
+	// 2**64.
+	m32 := Int64(1 << 32)
+	m64 := Int64(1).Lsh(64)
+	for _, x := range []int64{0, 1, -1, 12345, math.MaxInt32, math.MinInt32} {
+		for _, y := range []int64{1, -1, 99991, math.MaxInt32, math.MinInt32} {
+			a, b := Int32(x), Int32(y)
+			for _, c := range [][2]Number{
+				{a.AddWrap(b), a.Add(b)},
+				{a.SubWrap(b), a.Sub(b)},
+				{a.MulWrap(b), a.Mul(b)},
+			} {
+				if AddMod(c[0], Int32(0), m32).Cmp(AddMod(c[1], Int32(0), m32)) != 0 {
+					t.Errorf("%d, %d: %s vs %s", x, y, c[0], c[1])
+				}
+			}
+			p, q := Int64(x)<<31+7, Int64(y)<<32-3
+			for _, c := range [][2]Number{
+				{p.AddWrap(q), p.Add(q)},
+				{p.SubWrap(q), p.Sub(q)},
+				{p.MulWrap(q), p.Mul(q)},
+			} {
+				if AddMod(c[0], Int32(0), m64).Cmp(AddMod(c[1], Int32(0), m64)) != 0 {
+					t.Errorf("%d, %d: %s vs %s", p, q, c[0], c[1])
+				}
+			}
+		}
+	}
+}
+
+func ExampleInt32_AddChecked() {
+	fmt.Println(Int32(math.MaxInt32).AddChecked(Int32(1)))
+	fmt.Println(Int32(math.MaxInt32).AddChecked(Int64(1)))
+	fmt.Println(Int64(math.MinInt64).SubChecked(Int32(1)))
+	fmt.Println(Int64(1 << 32).MulChecked(Int64(1 << 31)))
+	fmt.Println(Int32(3).MulChecked(Float64(0.5)))
+	// Output:
+	// <nil> goarith: integer overflow
+	// 2147483648 <nil>
+	// <nil> goarith: integer overflow
+	// <nil> goarith: integer overflow
+	// 1.5 <nil>
+}
+
+func TestChecked(t *testing.T) {
+	type op func(Number) (Number, error)
+	for _, c := range []struct {
+		f    op
+		b    Number
+		want string // "" for ErrOverflow
+	}{
+		{Int32(math.MaxInt32).AddChecked, Int32(0), "goarith.Int32 2147483647"},
+		{Int32(math.MinInt32).AddChecked, Int32(-1), ""},
+		{Int32(math.MinInt32).SubChecked, Int32(1), ""},
+		{Int32(-1).SubChecked, Int32(math.MaxInt32), "goarith.Int32 -2147483648"},
+		{Int32(math.MinInt32).MulChecked, Int32(-1), ""},
+		{Int32(math.MinInt32).MulChecked, Int64(-1), "goarith.Int64 2147483648"},
+		{Int32(65536).MulChecked, Int32(32768), ""},
+		{Int64(math.MaxInt64).AddChecked, Int32(1), ""},
+		{Int64(math.MaxInt64).AddChecked, Int64(math.MinInt64), "goarith.Int32 -1"},
+		{Int64(0).SubChecked, Int64(math.MinInt64), ""},
+		{Int64(-1).SubChecked, Int64(math.MinInt64), "goarith.Int64 9223372036854775807"},
+		{Int64(math.MinInt64).MulChecked, Int32(-1), ""},
+		{Int64(math.MinInt64).MulChecked, Int32(1), "goarith.Int64 -9223372036854775808"},
+		{Int64(1 << 31).MulChecked, Int64(-1 << 32), "goarith.Int64 -9223372036854775808"},
+		{Int64(3037000500).MulChecked, Int64(3037000500), ""},
+		{Int64(1).AddChecked, (*BigInt)(new(big.Int).Lsh(bigOne, 64)), "*goarith.BigInt 18446744073709551617"},
+	} {
+		n, err := c.f(c.b)
+		if c.want == "" {
+			if err != ErrOverflow || n != nil {
+				t.Errorf("%s: %v %v, want ErrOverflow", c.b, n, err)
+			}
+		} else if got := fmt.Sprintf("%T %s", n, n); err != nil || got != c.want {
+			t.Errorf("%s: %s %v, want %s", c.b, got, err, c.want)
+		}
+	}
+	// The error path allocates nothing.
+	a, b := Int64(math.MaxInt64), Int64(2)
+	if n := testing.AllocsPerRun(100, func() { a.MulChecked(b) }); n != 0 {
+		t.Errorf("MulChecked allocates %v times on overflow", n)
+	}
+}
+
+func ExampleInt64_MulDiv() {
+	const sec = int64(time.Second)
+	for _, c := range [][3]Int64{
+		{1500, 1000, 3},
+		{math.MaxInt64, 3, 4},
+		{math.MaxInt64, math.MaxInt64, math.MaxInt64},
+		{math.MaxInt64, 2, 1},
+		{math.MinInt64, 1, -1},
+		{-7, 3, 2},
+		{Int64(90 * sec), 1, Int64(sec)},
+	} {
+		r := c[0].MulDiv(c[1], c[2])
+		fmt.Printf("%T %s\n", r, r)
+	}
+	// Output:
+	// goarith.Int32 500000
+	// goarith.Int64 6917529027641081855
+	// goarith.Int64 9223372036854775807
+	// *goarith.BigInt 18446744073709551614
+	// *goarith.BigInt 9223372036854775808
+	// goarith.Int32 -10
+	// goarith.Int32 90
+}
+
+func TestMulDiv(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 10000; i++ {
+		a, mul, div := Int64(r.Uint64()), Int64(r.Uint64()>>uint(i%64)), Int64(r.Uint64()>>uint(i%63))
+		if div == 0 {
+			continue
+		}
+		want := new(big.Int).Mul(big.NewInt(int64(a)), big.NewInt(int64(mul)))
+		want.Quo(want, big.NewInt(int64(div)))
+		got := a.MulDiv(mul, div)
+		if !Identical(got, (*BigInt)(want).reduce()) {
+			t.Errorf("%d.MulDiv(%d, %d) = %T %s, want %s", a, mul, div, got, got, want)
+		}
+	}
+	defer func() {
+		if r := recover(); r != "1.MulDiv(2, 0)" {
+			t.Errorf("MulDiv by zero: recovered %v", r)
+		}
+	}()
+	Int64(1).MulDiv(2, 0)
+}
@@ -0,0 +1,228 @@
+package goarith
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// FormatGrouped returns the string representation of n with sep
+// inserted between every three digits of the integer part, e.g.
+// "-1,234,567" or "1,234.5678".  The fractional part is left alone.
+// A Float64 or *BigFloat is written without exponent if its magnitude
+// is less than 1e21; otherwise, or if it is infinity or NaN,
+// FormatGrouped returns n.String() as is.
+func FormatGrouped(n Number, sep rune) string {
+	s := n.String()
+	switch x := n.(type) {
+	case Float64:
+		if !(math.Abs(float64(x)) < 1e21) {
+			return s
+		}
+		s = strconv.FormatFloat(float64(x), 'f', -1, 64)
+	case *BigFloat:
+		y := (*big.Float)(x)
+		if y.IsInf() || new(big.Float).Abs(y).Cmp(big.NewFloat(1e21)) >= 0 {
+			return s
+		}
+		s = y.Text('f', -1)
+	default:
+		return groupDigits(s, sep)
+	}
+	if !strings.Contains(s, ".") {
+		s += ".0"
+	}
+	return groupDigits(s, sep)
+}
+
+// groupDigits inserts sep between every three digits of the integer
+// part of the decimal numeral s.
+func groupDigits(s string, sep rune) string {
+	sign := ""
+	if s[0] == '-' {
+		sign, s = s[:1], s[1:]
+	}
+	frac := ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		s, frac = s[:i], s[i:]
+	}
+	var b strings.Builder
+	b.WriteString(sign)
+	for i, c := range s {
+		if i > 0 && (len(s)-i)%3 == 0 {
+			b.WriteRune(sep)
+		}
+		b.WriteRune(c)
+	}
+	b.WriteString(frac)
+	return b.String()
+}
+
+// FormatFloat returns the string representation of a in the format
+// format with the precision prec as strconv.FormatFloat does; e.g.
+// Float64(1234.5).FormatFloat('e', 2) returns "1.23e+03".
+// Unlike String, it does not append ".0" to integral values.
+func (a Float64) FormatFloat(format byte, prec int) string {
+	return strconv.FormatFloat(float64(a), format, prec, 64)
+}
+
+// PlainString returns the shortest string representation of a as
+// strconv.FormatFloat(a, 'g', -1, 64) does.  Unlike String, it does not
+// append ".0" to integral values, e.g. it returns "5" for Float64(5).
+func (a Float64) PlainString() string {
+	return a.FormatFloat('g', -1)
+}
+
+// IsExactString reports whether parsing a.String() back by
+// strconv.ParseFloat yields the identical bits of a.  It holds for every
+// finite a and the infinities, since String uses the shortest
+// representation that round-trips, including "-0.0" for negative zero.
+// For NaN, it holds only for the NaN which ParseFloat returns for "NaN",
+// since String drops the sign and the payload of NaN.
+func (a Float64) IsExactString() bool {
+	f, err := strconv.ParseFloat(a.String(), 64)
+	return err == nil && math.Float64bits(f) == a.Bits()
+}
+
+// FormatNumber returns the string representation of n.
+// If n is a Float64, it formats n in the format format with the
+// precision prec; see Float64.FormatFloat.  If n is a *BigFloat, it does
+// so by big.Float.Text.  If n is a *Decimal, it does so exactly for the
+// format 'f' with prec >= 0, rounding n with ties to even, and by
+// big.Float.Text otherwise.  If n is an integer, it ignores format and
+// prec and returns n.String(), which represents n exactly.
+func FormatNumber(n Number, format byte, prec int) string {
+	switch x := n.(type) {
+	case Float64:
+		return x.FormatFloat(format, prec)
+	case *BigFloat:
+		return (*big.Float)(x).Text(format, prec)
+	case *Decimal:
+		if format == 'f' && prec >= 0 && prec <= math.MaxInt32 {
+			return x.roundTo(int32(prec), ToNearestEven).String()
+		}
+		return toBigFloat(x).Text(format, prec)
+	}
+	return n.String()
+}
+
+// formatInt implements fmt.Formatter for an integer x of the type typ
+// by big.Int.Format, which supports the verbs %b, %o, %O, %d, %x, %X,
+// %s and %v and the flags '+', '-', '#', ' ' and '0'.
+func formatInt(f fmt.State, verb rune, x *big.Int, typ string) {
+	switch verb {
+	case 'b', 'o', 'O', 'd', 'x', 'X', 's', 'v':
+		x.Format(f, verb)
+	default:
+		fmt.Fprintf(f, "%%!%c(%s=%s)", verb, typ, x.String())
+	}
+}
+
+// formatString writes s, which is the String() of a non-integer, for
+// the verbs %s and %v honoring the width and the flags '+' and '-'.
+func formatString(f fmt.State, s string) {
+	if f.Flag('+') && s[0] != '-' && s[0] != '+' {
+		s = "+" + s
+	}
+	fmt.Fprintf(f, fmt.FormatString(f, 's'), s)
+}
+
+// Format implements fmt.Formatter.  See big.Int.Format for the verbs
+// and flags supported.
+func (a Int32) Format(f fmt.State, verb rune) {
+	formatInt(f, verb, big.NewInt(int64(a)), "goarith.Int32")
+}
+
+// Format implements fmt.Formatter.  See big.Int.Format for the verbs
+// and flags supported.
+func (a Int64) Format(f fmt.State, verb rune) {
+	formatInt(f, verb, big.NewInt(int64(a)), "goarith.Int64")
+}
+
+// Format implements fmt.Formatter.  It formats a as fmt does a float64
+// except that %s and %v write a.String(), e.g. "2.0" for 2.
+func (a Float64) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 's', 'v':
+		formatString(f, a.String())
+	default:
+		fmt.Fprintf(f, fmt.FormatString(f, verb), float64(a))
+	}
+}
+
+// Format implements fmt.Formatter.  See big.Int.Format for the verbs
+// and flags supported.
+func (a *BigInt) Format(f fmt.State, verb rune) {
+	formatInt(f, verb, (*big.Int)(a), "*goarith.BigInt")
+}
+
+// Format implements fmt.Formatter.  It formats a by big.Float.Format
+// except that %s and %v write a.String().
+func (a *BigFloat) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 's', 'v':
+		formatString(f, a.String())
+	default:
+		(*big.Float)(a).Format(f, verb)
+	}
+}
+
+// Format implements fmt.Formatter.  It formats a as fmt does a
+// big.Float except that %s and %v write a.String(), e.g. "0.10".
+func (a *Decimal) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 's', 'v':
+		formatString(f, a.String())
+	default:
+		toBigFloat(a).Format(f, verb)
+	}
+}
+
+// DigitCount returns the number of decimal digits in the integer part
+// of n, excluding the sign, e.g. 3 for Int32(-999) and Float64(123.45).
+// It returns 1 for any n whose integer part is 0.
+// It panics if n is infinite or NaN.
+func DigitCount(n Number) int {
+	switch x := n.(type) {
+	case Int32:
+		return digitCountUint64(absInt64(int64(x)))
+	case Int64:
+		return digitCountUint64(absInt64(int64(x)))
+	case *BigInt:
+		return digitCountBig((*big.Int)(x))
+	}
+	if n.IsInf(0) || n.IsNaN() {
+		panic(fmt.Sprintf("DigitCount(%s)", n.String()))
+	}
+	return digitCountBig(ToBigRat(n.Trunc()).Num())
+}
+
+// digitCountUint64 returns the number of decimal digits of u.
+func digitCountUint64(u uint64) int {
+	d := 1
+	for u >= 10 {
+		u /= 10
+		d++
+	}
+	return d
+}
+
+// digitCountBig returns the number of decimal digits of |x|.
+// It estimates the count from the bit length and corrects it by
+// comparing |x| with a power of 10.
+func digitCountBig(x *big.Int) int {
+	if x.IsInt64() {
+		return digitCountUint64(absInt64(x.Int64()))
+	}
+	// 2**(n-1) <= |x| < 2**n gives d = floor((n-1) log10 2) + 1 or d + 1.
+	d := int(float64(x.BitLen()-1)*math.Log10(2)) + 1
+	p := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(d)), nil)
+	if x.CmpAbs(p) >= 0 {
+		d++
+	} else if x.CmpAbs(p.Quo(p, big.NewInt(10))) < 0 { // by rounding error
+		d--
+	}
+	return d
+}
@@ -0,0 +1,194 @@
+package goarith
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func ExampleFormatGrouped() {
+	x, _ := new(big.Int).SetString("-123456789012345678901234567890", 10)
+	fmt.Println(FormatGrouped(Int32(0), ','))
+	fmt.Println(FormatGrouped(Int32(-123), ','))
+	fmt.Println(FormatGrouped(Int32(-1234), ','))
+	fmt.Println(FormatGrouped(Int64(1234567), ','))
+	fmt.Println(FormatGrouped((*BigInt)(x), ','))
+	fmt.Println(FormatGrouped(Float64(-1234567.8901), ' '))
+	fmt.Println(FormatGrouped(Float64(1e20), ','))
+	fmt.Println(FormatGrouped(Float64(1e21), ','))
+	fmt.Println(FormatGrouped(Float64(1.5e-7), ','))
+	fmt.Println(FormatGrouped(Int64(1234567), '\''))
+	// Output:
+	// 0
+	// -123
+	// -1,234
+	// 1,234,567
+	// -123,456,789,012,345,678,901,234,567,890
+	// -1 234 567.8901
+	// 100,000,000,000,000,000,000.0
+	// 1e+21
+	// 0.00000015
+	// 1'234'567
+}
+
+func ExampleFloat64_FormatFloat() {
+	a := Float64(1234.5)
+	for _, f := range []byte{'e', 'E', 'f', 'g', 'G', 'x', 'b'} {
+		fmt.Printf("%c %s %s\n", f, a.FormatFloat(f, 2), a.FormatFloat(f, -1))
+	}
+	for _, a := range []Float64{Float64(math.Inf(1)), Float64(math.Inf(-1)),
+		Float64(math.NaN())} {
+		fmt.Println(a.FormatFloat('f', 2), a.FormatFloat('e', -1))
+	}
+	// Output:
+	// e 1.23e+03 1.2345e+03
+	// E 1.23E+03 1.2345E+03
+	// f 1234.50 1234.5
+	// g 1.2e+03 1234.5
+	// G 1.2E+03 1234.5
+	// x 0x1.35p+10 0x1.34ap+10
+	// b 5429388417957888p-42 5429388417957888p-42
+	// +Inf +Inf
+	// -Inf -Inf
+	// NaN NaN
+}
+
+func ExampleFloat64_PlainString() {
+	for _, a := range []Float64{5, Float64(math.Copysign(0, -1)), 2.5, 1e21, 1e-7,
+		Float64(math.Inf(1))} {
+		fmt.Println(a.String(), a.PlainString())
+	}
+	// Output:
+	// 5.0 5
+	// -0.0 -0
+	// 2.5 2.5
+	// 1e+21 1e+21
+	// 1e-07 1e-07
+	// +Inf +Inf
+}
+
+func ExampleFloat64_IsExactString() {
+	nan := Float64(math.NaN())
+	for _, a := range []Float64{0.1, 1.0 / 3, Float64(math.Copysign(0, -1)), 5e-324, math.MaxFloat64,
+		Float64(math.Inf(-1)), nan, Float64(math.Copysign(math.NaN(), -1))} {
+		fmt.Println(a, a.IsExactString())
+	}
+	// Output:
+	// 0.1 true
+	// 0.3333333333333333 true
+	// -0.0 true
+	// 5e-324 true
+	// 1.7976931348623157e+308 true
+	// -Inf true
+	// NaN true
+	// NaN false
+}
+
+func TestIsExactString(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 10000; i++ {
+		a := Float64FromBits(r.Uint64())
+		if a.IsNaN() {
+			continue
+		}
+		if !a.IsExactString() {
+			t.Errorf("%s (%#x) does not round-trip", a, a.Bits())
+		}
+	}
+}
+
+func ExampleFormatNumber() {
+	x, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	fmt.Println(FormatNumber(Float64(2.0/3), 'f', 3))
+	fmt.Println(FormatNumber(Int32(12), 'f', 3))
+	fmt.Println(FormatNumber(Int64(1<<40), 'e', 2))
+	fmt.Println(FormatNumber((*BigInt)(x), 'e', 2))
+	// Output:
+	// 0.667
+	// 12
+	// 1099511627776
+	// 123456789012345678901234567890
+}
+
+func ExampleInt64_Format() {
+	x, _ := new(big.Int).SetString("-123456789012345678901234567890", 10)
+	fmt.Printf("%08x|%+d|%#o|%b\n", Int64(255), Int32(7), Int32(8), Int32(-5))
+	fmt.Printf("%+d|%x|%40v|\n", (*BigInt)(x), (*BigInt)(x), (*BigInt)(x))
+	fmt.Printf("%10.3f|%e|%-6v|%+v|%v\n", Float64(3.14159), Float64(1234.5),
+		Float64(2), Float64(2), Float64(math.Inf(-1)))
+	// Output:
+	// 000000ff|+7|010|-101
+	// -123456789012345678901234567890|-18ee90ff6c373e0ee4e3f0ad2|         -123456789012345678901234567890|
+	//      3.142|1.234500e+03|2.0   |+2.0|-Inf
+}
+
+func TestFormat(t *testing.T) {
+	x, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	for _, c := range []struct {
+		format string
+		n      Number
+		want   string
+	}{
+		{"%08x", Int64(255), "000000ff"},
+		{"%X", Int64(-255), "-FF"},
+		{"%#x", Int32(255), "0xff"},
+		{"%5d", Int32(42), "   42"},
+		{"%-5d|", Int32(42), "42   |"},
+		{"%05d", Int32(-42), "-0042"},
+		{"%+d", (*BigInt)(new(big.Int).Neg(x)), "-123456789012345678901234567890"},
+		{"%+d", (*BigInt)(x), "+123456789012345678901234567890"},
+		{"%s", Int64(1 << 40), "1099511627776"},
+		{"%v", Float64(1e21), "1e+21"},
+		{"%.2f", Float64(2.345), "2.35"},
+		{"%G", Float64(1e-10), "1E-10"},
+		{"%q", Int32(1), "%!q(goarith.Int32=1)"},
+		{"%.10f", NewBigFloat(Float64(0.5), 0), "0.5000000000"},
+		{"%8v", NewBigFloat(Float64(2), 0), "     2.0"},
+	} {
+		if got := fmt.Sprintf(c.format, c.n); got != c.want {
+			t.Errorf("Sprintf(%q, %s) = %q, want %q", c.format, c.n, got, c.want)
+		}
+	}
+}
+
+func ExampleDigitCount() {
+	for _, n := range []Number{Int32(0), Int32(9), Int32(-999), Int32(1000),
+		Int64(math.MinInt64), Float64(123.45), Float64(-0.5), Float64(1e20)} {
+		fmt.Print(DigitCount(n), " ")
+	}
+	fmt.Println()
+	// Output:
+	// 1 1 3 4 19 3 1 21
+}
+
+func TestDigitCount(t *testing.T) {
+	ten := big.NewInt(10)
+	p := big.NewInt(1)
+	for d := 1; d <= 400; d++ {
+		// p = 10**(d-1) has d digits and p - 1 has d-1 digits.
+		q := new(big.Int).Sub(p, bigOne)
+		for _, c := range []struct {
+			x    *big.Int
+			want int
+		}{
+			{p, d},
+			{new(big.Int).Neg(p), d},
+			{q, d - 1},
+			{new(big.Int).Neg(q), d - 1},
+		} {
+			if c.want == 0 {
+				c.want = 1 // for 0
+			}
+			n := (*BigInt)(c.x).reduce()
+			if got := DigitCount(n); got != c.want {
+				t.Errorf("DigitCount(%s) = %d, want %d", c.x, got, c.want)
+			} else if got != len(strings.TrimPrefix(c.x.String(), "-")) {
+				t.Errorf("DigitCount(%s) = %d", c.x, got)
+			}
+		}
+		p.Mul(p, ten)
+	}
+}
@@ -1,3 +1,3 @@
 module github.com/nukata/goarith
 
-go 1.13
+go 1.19
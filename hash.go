@@ -0,0 +1,128 @@
+package goarith
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash"
+	"hash/fnv"
+	"math"
+	"math/big"
+)
+
+// writeInt writes the sign and the big-endian magnitude of an integer,
+// the latter in the same form as big.Int.Bytes.
+func writeInt(h hash.Hash64, neg bool, magnitude []byte) {
+	if neg {
+		h.Write([]byte{'-'})
+	} else {
+		h.Write([]byte{'+'})
+	}
+	h.Write(magnitude)
+}
+
+// writeInt64 writes x in the same way as writeInt.
+func writeInt64(h hash.Hash64, x int64) {
+	u := uint64(x)
+	if x < 0 {
+		u = -u
+	}
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], u)
+	i := 0
+	for i < len(b) && b[i] == 0 {
+		i++
+	}
+	writeInt(h, x < 0, b[i:])
+}
+
+// Hash returns a hash value of n which is stable across the concrete
+// types: if a and b have the same numeric value, Hash(a) == Hash(b).
+// For example, Int32(5), Int64(5), (*BigInt)(big.NewInt(5)) and
+// Float64(5) have the same hash value.
+// An integer is hashed by its sign and the bytes of its magnitude, and a
+// non-integral Float64 by its bits.  All NaNs have the same hash value.
+// A *BigFloat or *Decimal is hashed as the equal integer or Float64 if
+// any, e.g. 0.50 as Float64(0.5), or by its reduced fraction otherwise;
+// a *Decimal which is not a binary fraction, e.g. 0.1, is hashed by its
+// coefficient and scale without trailing zeros instead.
+func Hash(n Number) uint64 {
+	h := fnv.New64a()
+	switch x := n.(type) {
+	case Int32:
+		writeInt64(h, int64(x))
+	case Int64:
+		writeInt64(h, int64(x))
+	case Float64:
+		if x.IsInteger() {
+			return Hash(fromIntegral(float64(x)))
+		}
+		f := float64(x)
+		if math.IsNaN(f) {
+			f = math.NaN() // Ignore the sign and payload.
+		}
+		var b [9]byte
+		b[0] = 'f'
+		binary.BigEndian.PutUint64(b[1:], math.Float64bits(f))
+		h.Write(b[:])
+	case *BigInt:
+		y := (*big.Int)(x)
+		writeInt(h, y.Sign() < 0, y.Bytes())
+	case *BigFloat:
+		y := (*big.Float)(x)
+		if y.IsInt() {
+			z, _ := y.Int(nil)
+			return Hash((*BigInt)(z))
+		} else if f, acc := y.Float64(); acc == big.Exact {
+			return Hash(Float64(f))
+		}
+		r, _ := y.Rat(nil)
+		h.Write([]byte{'r'})
+		h.Write([]byte(r.String()))
+	case *Decimal:
+		c, s := x.normalize()
+		if s <= 0 {
+			return Hash((*BigInt)(new(big.Int).Mul(c, pow10(-s))))
+		} else if float64(c.BitLen()) >= float64(s)*math.Log2(5) {
+			// 5**s may divide c, and then x may equal a *BigFloat.
+			r := new(big.Rat).SetFrac(c, pow10(s))
+			if f, exact := r.Float64(); exact {
+				return Hash(Float64(f))
+			}
+			h.Write([]byte{'r'})
+			h.Write([]byte(r.String()))
+		} else {
+			// x is not a binary fraction and equals no other type.
+			h.Write([]byte{'d'})
+			writeInt(h, c.Sign() < 0, c.Bytes())
+			writeInt64(h, s)
+		}
+	default:
+		panic(fmt.Sprintf("Hash(%s)", n.String()))
+	}
+	return h.Sum64()
+}
+
+// combineHash mixes the hash values of a and b in the same way as
+// boost::hash_combine.
+func combineHash(a, b Number) uint64 {
+	seed := Hash(a)
+	return seed ^ (Hash(b) + 0x9e3779b97f4a7c15 + (seed << 6) + (seed >> 2))
+}
+
+// Combine methods
+
+func (a Int32) Combine(b Number) uint64 {
+	return combineHash(a, b)
+}
+
+func (a Int64) Combine(b Number) uint64 {
+	return combineHash(a, b)
+}
+
+func (a Float64) Combine(b Number) uint64 {
+	return combineHash(a, b)
+}
+
+func (a *BigInt) Combine(b Number) uint64 {
+	return combineHash(a, b)
+}
@@ -0,0 +1,49 @@
+package goarith
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+)
+
+func ExampleInt32_Combine() {
+	fmt.Println(Int32(1).Combine(Int32(2)) == Int32(2).Combine(Int32(1)))
+	fmt.Println(Int32(1).Combine(Int32(2)) == Int64(1).Combine(Float64(2)))
+	fmt.Println(Int32(1).Combine(Int32(2)) ==
+		(*BigInt)(big.NewInt(1)).Combine((*BigInt)(big.NewInt(2))))
+	fmt.Println(Float64(0.5).Combine(Int32(-7)) == Float64(0.5).Combine(Int64(-7)))
+	// Output:
+	// false
+	// true
+	// true
+	// true
+}
+
+func ExampleHash() {
+	x, _ := new(big.Int).SetString("-123456789012345678901234567890", 10)
+	y, _ := new(big.Float).SetInt(x).Float64()
+	z, _ := new(big.Float).SetFloat64(y).Int(nil)
+	groups := [][]Number{
+		{Int32(5), Int64(5), (*BigInt)(big.NewInt(5)), Float64(5)},
+		{Int32(0), Int64(0), (*BigInt)(new(big.Int)), Float64(0), Float64(math.Copysign(0, -1))},
+		{Int64(-1 << 40), (*BigInt)(big.NewInt(-1 << 40)), Float64(-1 << 40)},
+		{(*BigInt)(z), Float64(y)},
+		{Float64(0.5), Float64(0.5)},
+	}
+	for _, g := range groups {
+		for _, n := range g {
+			fmt.Print(" ", Hash(n) == Hash(g[0]))
+		}
+		fmt.Println()
+	}
+	fmt.Println(Hash(Int32(1)) == Hash(Int32(-1)))
+	fmt.Println(Hash(Float64(0.5)) == Hash(Float64(-0.5)))
+	// Output:
+	//  true true true true
+	//  true true true true true
+	//  true true true
+	//  true true
+	//  true true
+	// false
+	// false
+}
@@ -0,0 +1,82 @@
+package goarith
+
+import (
+	"math"
+	"math/big"
+)
+
+// logNumber returns log(n), where log is math.Log, math.Log2 or
+// math.Log10 and log2 is log(2).  A *BigInt, *BigFloat or *Decimal
+// beyond the range of normal float64 values is split into its mantissa
+// m and exponent e so that log(n) = log(m) + e * log2 is computed
+// without overflow.
+func logNumber(n Number, log func(float64) float64, log2 float64) Float64 {
+	f := float64(asFloat64(n))
+	switch n.(type) {
+	case *BigInt, *BigFloat, *Decimal:
+		if math.Abs(f) < 0x1p-1022 || math.IsInf(f, 0) {
+			x := toBigFloat(n)
+			if x.Sign() > 0 && !x.IsInf() {
+				m := new(big.Float)
+				e := x.MantExp(m)
+				mf, _ := m.Float64()
+				return Float64(log(mf) + float64(e)*log2)
+			}
+		}
+	}
+	return Float64(log(f))
+}
+
+// Log methods
+
+func (a Int32) Log() Float64 {
+	return Float64(math.Log(float64(a)))
+}
+
+func (a Int64) Log() Float64 {
+	return Float64(math.Log(float64(a)))
+}
+
+func (a Float64) Log() Float64 {
+	return Float64(math.Log(float64(a)))
+}
+
+func (a *BigInt) Log() Float64 {
+	return logNumber(a, math.Log, math.Ln2)
+}
+
+// Log2 methods
+
+func (a Int32) Log2() Float64 {
+	return Float64(math.Log2(float64(a)))
+}
+
+func (a Int64) Log2() Float64 {
+	return Float64(math.Log2(float64(a)))
+}
+
+func (a Float64) Log2() Float64 {
+	return Float64(math.Log2(float64(a)))
+}
+
+func (a *BigInt) Log2() Float64 {
+	return logNumber(a, math.Log2, 1)
+}
+
+// Log10 methods
+
+func (a Int32) Log10() Float64 {
+	return Float64(math.Log10(float64(a)))
+}
+
+func (a Int64) Log10() Float64 {
+	return Float64(math.Log10(float64(a)))
+}
+
+func (a Float64) Log10() Float64 {
+	return Float64(math.Log10(float64(a)))
+}
+
+func (a *BigInt) Log10() Float64 {
+	return logNumber(a, math.Log10, math.Log10(2))
+}
@@ -0,0 +1,54 @@
+package goarith
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func ExampleInt64_Log2() {
+	fmt.Println(Int64(8).Log2(), Int32(1000).Log10(), Float64(math.E).Log())
+	fmt.Println(Int32(0).Log(), Int32(-1).Log2(), Float64(math.Inf(1)).Log10())
+	x, _ := new(big.Int).SetString("1"+strings.Repeat("0", 400), 10)
+	fmt.Println((*BigInt)(x).Log10(), Float64(1e300).Mul(Float64(1e300)).(Float64).Log10())
+	// Output:
+	// 3.0 3.0 1.0
+	// -Inf NaN +Inf
+	// 400.0 +Inf
+}
+
+func TestBigLog(t *testing.T) {
+	for _, n := range []int{1, 10, 100, 308, 309, 1000, 5000} {
+		x, _ := new(big.Int).SetString("1"+strings.Repeat("0", n), 10)
+		a := (*BigInt)(x)
+		if got := a.Log10(); math.Abs(float64(got)-float64(n)) > 1e-9 {
+			t.Errorf("10**%d: Log10 = %s", n, got)
+		}
+		if got := a.Log(); math.Abs(float64(got)/math.Ln10-float64(n)) > 1e-9 {
+			t.Errorf("10**%d: Log = %s", n, got)
+		}
+		if got := a.Log2(); math.Abs(float64(got)-float64(n)*math.Log2(10)) > 1e-9 {
+			t.Errorf("10**%d: Log2 = %s", n, got)
+		}
+		if d := DigitCount(a); int(a.Log10())+1 != d {
+			t.Errorf("10**%d: Log10 = %s, DigitCount = %d", n, a.Log10(), d)
+		}
+		// 10**-n underflows float64 for a large n.
+		r := new(big.Float).SetPrec(200).Quo(big.NewFloat(1), new(big.Float).SetInt(x))
+		if got := (*BigFloat)(r).Log10(); math.Abs(float64(got)+float64(n)) > 1e-9 {
+			t.Errorf("10**-%d: Log10 = %s", n, got)
+		}
+	}
+	if got := (*BigInt)(big.NewInt(-1)).Lsh(2000).(*BigInt).Log(); !got.IsNaN() {
+		t.Errorf("Log(-2**2000) = %s", got)
+	}
+	if got := NewBigFloat(Int32(0), 0).Log2(); !got.IsInf(-1) {
+		t.Errorf("Log2(BigFloat 0) = %s", got)
+	}
+	inf := NewBigFloat(Float64(math.Inf(1)), 0)
+	if got := inf.Log(); !got.IsInf(1) {
+		t.Errorf("Log(BigFloat +Inf) = %s", got)
+	}
+}
@@ -0,0 +1,131 @@
+package goarith
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// AddMod returns (a + b) mod m, where a, b and m are Int32, Int64 or
+// *BigInt.  The result r satisfies 0 <= r < |m|.
+// It panics if some of them are not integers or m is zero.
+func AddMod(a, b, m Number) Number {
+	x, y, z := toBigInt(a), toBigInt(b), toBigInt(m)
+	if x == nil || y == nil || z == nil {
+		panic(fmt.Sprintf("AddMod(%s, %s, %s)", a.String(), b.String(), m.String()))
+	}
+	x.Add(x, y)
+	return (*BigInt)(x.Mod(x, z)).reduce()
+}
+
+// MulMod returns (a * b) mod m, where a, b and m are Int32, Int64 or
+// *BigInt.  The result r satisfies 0 <= r < |m|.
+// It panics if some of them are not integers or m is zero.
+func MulMod(a, b, m Number) Number {
+	x, y, z := toBigInt(a), toBigInt(b), toBigInt(m)
+	if x == nil || y == nil || z == nil {
+		panic(fmt.Sprintf("MulMod(%s, %s, %s)", a.String(), b.String(), m.String()))
+	}
+	x.Mul(x, y)
+	return (*BigInt)(x.Mod(x, z)).reduce()
+}
+
+// DotMod returns the dot product of a and b modulo m, i.e.
+// (a[0]*b[0] + a[1]*b[1] + ...) mod m, keeping every intermediate
+// result less than |m| by MulMod and AddMod.
+// It returns an error if a and b differ in length, if some element or m
+// is not an integer, or if m is zero.
+func DotMod(a, b []Number, m Number) (Number, error) {
+	if len(a) != len(b) {
+		return nil, fmt.Errorf("goarith: lengths differ: %d and %d", len(a), len(b))
+	}
+	if z := toBigInt(m); z == nil || z.Sign() == 0 {
+		return nil, fmt.Errorf("goarith: invalid modulus %s", m.String())
+	}
+	for i := range a {
+		if toBigInt(a[i]) == nil || toBigInt(b[i]) == nil {
+			return nil, fmt.Errorf("goarith: non-integer at index %d: %s, %s",
+				i, a[i].String(), b[i].String())
+		}
+	}
+	var sum Number = Int32(0)
+	for i := range a {
+		sum = AddMod(sum, MulMod(a[i], b[i], m), m)
+	}
+	return sum, nil
+}
+
+// ExpMod returns base**exp mod m, where base, exp and m are Int32, Int64
+// or *BigInt.  The result r satisfies 0 <= r < |m|.
+// If exp is negative, it returns the modular inverse of base raised to
+// -exp, which exists if base and m are coprime.
+// It panics if some of them are not integers, if m is zero, or if exp is
+// negative and base is not invertible modulo m.
+func ExpMod(base, exp, m Number) Number {
+	x, y, z := toBigInt(base), toBigInt(exp), toBigInt(m)
+	if x == nil || y == nil || z == nil || z.Sign() == 0 {
+		panic(fmt.Sprintf("ExpMod(%s, %s, %s)", base.String(), exp.String(), m.String()))
+	}
+	r := new(big.Int).Exp(x, y, z)
+	if r == nil {
+		panic(fmt.Sprintf("ExpMod(%s, %s, %s): not invertible",
+			base.String(), exp.String(), m.String()))
+	}
+	return (*BigInt)(r).reduce()
+}
+
+// ModInverse returns the multiplicative inverse of a modulo m and true,
+// where a and m are Int32, Int64 or *BigInt.  The inverse r satisfies
+// 0 <= r < |m|.  If a and m are not coprime, it returns nil and false.
+// It panics if a or m is not an integer or m is zero.
+func ModInverse(a, m Number) (Number, bool) {
+	x, z := toBigInt(a), toBigInt(m)
+	if x == nil || z == nil || z.Sign() == 0 {
+		panic(fmt.Sprintf("ModInverse(%s, %s)", a.String(), m.String()))
+	}
+	r := new(big.Int).ModInverse(x, z)
+	if r == nil {
+		return nil, false
+	}
+	return (*BigInt)(r).reduce(), true
+}
+
+// ProbablyPrime reports whether a is probably prime by
+// big.Int.ProbablyPrime with n Miller-Rabin tests.
+func (a *BigInt) ProbablyPrime(n int) bool {
+	return (*big.Int)(a).ProbablyPrime(n)
+}
+
+// ProbablyPrime reports whether x is probably prime by
+// big.Int.ProbablyPrime with n Miller-Rabin tests.
+// An integral Float64 or *BigFloat is tested as the equal integer.
+// It returns false for a non-integer, infinity and NaN.
+// It panics if n is negative.
+func ProbablyPrime(x Number, n int) bool {
+	z := toBigInt(x)
+	if z == nil {
+		if !x.IsInteger() {
+			return false
+		}
+		z = ToBigRat(x).Num()
+	}
+	return z.ProbablyPrime(n)
+}
+
+// Divides reports whether a divides b evenly, i.e. b / a is an integer.
+// Integers are tested exactly by the remainder.  For a Float64 or
+// *BigFloat, it tests the exact value without any tolerance, as
+// math.Mod(b, a) == 0 does; e.g. Float64(0.5) divides Float64(1.5), but
+// Float64(0.1) does not divide Float64(0.3).
+// It returns false if a is zero or a or b is infinite or NaN.
+func Divides(a, b Number) bool {
+	if x, ok := fixedInt(a); ok {
+		if y, ok := fixedInt(b); ok {
+			return x != 0 && y%x == 0
+		}
+	}
+	x, y := ToBigRat(a), ToBigRat(b)
+	if x == nil || y == nil || x.Sign() == 0 {
+		return false
+	}
+	return y.Quo(y, x).IsInt()
+}
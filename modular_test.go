@@ -0,0 +1,199 @@
+package goarith
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+)
+
+func ExampleAddMod() {
+	fmt.Println(AddMod(Int32(5), Int32(4), Int32(7)).String())
+	fmt.Println(AddMod(Int32(-5), Int32(1), Int32(7)).String())
+	fmt.Println(AddMod(Int64(1<<62), Int64(1<<62), Int64(1000)).String())
+	// Output:
+	// 2
+	// 3
+	// 808
+}
+
+func ExampleMulMod() {
+	x, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	r := MulMod((*BigInt)(x), (*BigInt)(x), Int64(1000000007))
+	fmt.Printf("%T %s\n", r, r.String())
+	// Output:
+	// goarith.Int32 562701352
+}
+
+func ExampleDotMod() {
+	a := []Number{Int32(3), Int64(1 << 40), Int32(-7)}
+	b := []Number{Int32(5), Int32(1 << 30), Int64(11)}
+	m := Int32(97)
+	r, err := DotMod(a, b, m)
+	fmt.Println(r.String(), err)
+
+	// The unbounded dot product (which is positive here) reduced mod m
+	var sum Number = Int32(0)
+	for i := range a {
+		sum = sum.Add(a[i].Mul(b[i]))
+	}
+	_, rem := sum.QuoRem(m)
+	fmt.Println(rem.String())
+
+	_, err = DotMod(a, b[:2], m)
+	fmt.Println(err)
+	_, err = DotMod(a, []Number{Int32(1), Float64(2), Int32(3)}, m)
+	fmt.Println(err)
+	// Output:
+	// 59 <nil>
+	// 59
+	// goarith: lengths differ: 3 and 2
+	// goarith: non-integer at index 1: 1099511627776, 2.0
+}
+
+func ExampleExpMod() {
+	fmt.Println(ExpMod(Int32(2), Int32(10), Int32(1000)))
+	fmt.Println(ExpMod(Int32(-2), Int32(3), Int32(7)))
+	fmt.Println(ExpMod(Int32(3), Int32(-1), Int32(7)))
+	fmt.Println(ExpMod(Int32(3), Int32(-2), Int32(7)))
+	p, _ := new(big.Int).SetString("170141183460469231731687303715884105727", 10)
+	r := ExpMod(Int64(123456789), (*BigInt)(new(big.Int).Sub(p, bigOne)), (*BigInt)(p))
+	fmt.Printf("%T %s\n", r, r) // by Fermat's little theorem
+	// Output:
+	// 24
+	// 6
+	// 5
+	// 4
+	// goarith.Int32 1
+}
+
+func TestExpModPanics(t *testing.T) {
+	for _, c := range [][3]Number{
+		{Float64(2), Int32(3), Int32(5)},
+		{Int32(2), Float64(3), Int32(5)},
+		{Int32(2), Int32(3), Float64(5)},
+		{Int32(2), Int32(3), Int32(0)},
+		{Int32(2), Int32(-1), Int32(4)},
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("ExpMod(%s, %s, %s) did not panic", c[0], c[1], c[2])
+				}
+			}()
+			ExpMod(c[0], c[1], c[2])
+		}()
+	}
+}
+
+func ExampleModInverse() {
+	fmt.Println(ModInverse(Int32(3), Int32(7)))
+	fmt.Println(ModInverse(Int32(-3), Int32(7)))
+	fmt.Println(ModInverse(Int32(6), Int32(9)))
+	fmt.Println(ModInverse(Int32(1), Int32(1)))
+	// Output:
+	// 5 true
+	// 2 true
+	// <nil> false
+	// 0 true
+}
+
+func TestModInverse(t *testing.T) {
+	// 2**127 - 1 is a Mersenne prime.
+	p := new(big.Int).Sub(new(big.Int).Lsh(bigOne, 127), bigOne)
+	m := (*BigInt)(p)
+	for _, a := range []Number{Int32(2), Int64(-1 << 40), m.Sub(Int32(1)),
+		m.Mul(Int32(3)).Add(Int32(5))} {
+		r, ok := ModInverse(a, m)
+		if !ok {
+			t.Fatalf("ModInverse(%s, %s) = %v, false", a, m, r)
+		}
+		if e := MulMod(a, r, m); e.Cmp(Int32(1)) != 0 {
+			t.Errorf("%s * %s mod %s = %s", a, r, m, e)
+		}
+	}
+	if r, ok := ModInverse(m.Mul(Int32(2)), m); ok {
+		t.Errorf("ModInverse(2p, p) = %s, true", r)
+	}
+	if r, ok := ModInverse(Int64(1<<40), Int64(1<<20)); ok {
+		t.Errorf("ModInverse(2**40, 2**20) = %s, true", r)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("ModInverse(2.0, 5) did not panic")
+		}
+	}()
+	ModInverse(Float64(2), Int32(5))
+}
+
+func ExampleProbablyPrime() {
+	for _, x := range []Number{Int32(2), Int32(97), Int32(91), Int32(-7),
+		Int64(1<<61 - 1), Float64(13), Float64(13.5)} {
+		fmt.Println(x, ProbablyPrime(x, 20))
+	}
+	// Output:
+	// 2 true
+	// 97 true
+	// 91 false
+	// -7 false
+	// 2305843009213693951 true
+	// 13.0 true
+	// 13.5 false
+}
+
+func TestProbablyPrime(t *testing.T) {
+	// Carmichael numbers fool the Fermat test but not Miller-Rabin.
+	for _, c := range []int64{561, 1105, 1729, 2465, 2821, 6601, 8911,
+		41041, 825265, 321197185, 5394826801} {
+		if ProbablyPrime(Int64(c).Reduce(), 20) {
+			t.Errorf("ProbablyPrime(%d) = true", c)
+		}
+	}
+	p := new(big.Int).Sub(new(big.Int).Lsh(bigOne, 521), bigOne)
+	if !(*BigInt)(p).ProbablyPrime(20) {
+		t.Error("2**521 - 1 is not prime")
+	}
+	if q := p.Mul(p, big.NewInt(3)); (*BigInt)(q).ProbablyPrime(20) {
+		t.Error("3 * (2**521 - 1) is prime")
+	}
+	if ProbablyPrime(Float64(math.Inf(1)), 20) || ProbablyPrime(Float64(math.NaN()), 20) {
+		t.Error("ProbablyPrime(Inf or NaN) = true")
+	}
+}
+
+func TestDivides(t *testing.T) {
+	p := new(big.Int).Sub(new(big.Int).Lsh(bigOne, 127), bigOne)
+	q := new(big.Int).Sub(new(big.Int).Lsh(bigOne, 89), bigOne)
+	pq := (*BigInt)(new(big.Int).Mul(p, q))
+	for _, c := range []struct {
+		a, b Number
+		want bool
+	}{
+		{Int32(3), Int32(12), true},
+		{Int32(5), Int32(12), false},
+		{Int32(-3), Int32(12), true},
+		{Int32(3), Int32(-12), true},
+		{Int32(7), Int32(0), true},
+		{Int32(0), Int32(0), false},
+		{Int32(0), Int32(5), false},
+		{Int32(-1), Int64(math.MinInt64), true},
+		{Int64(1 << 40), Int64(1 << 62), true},
+		{(*BigInt)(p), pq, true},
+		{(*BigInt)(q), pq, true},
+		{pq, (*BigInt)(p), false},
+		{(*BigInt)(p), pq.Add(Int32(1)), false},
+		{Int32(2), pq.Add(Int32(1)), true},
+		{Float64(0.5), Float64(1.5), true},
+		{Float64(0.1), Float64(0.3), false},
+		{Float64(2), Int32(6), true},
+		{Int32(4), Float64(6), false},
+		{Float64(0), Float64(1), false},
+		{Float64(math.Inf(1)), Float64(1), false},
+		{Float64(1), Float64(math.NaN()), false},
+		{Float64(3), (*BigInt)(new(big.Int).Mul(p, big.NewInt(3))), true},
+	} {
+		if got := Divides(c.a, c.b); got != c.want {
+			t.Errorf("Divides(%s, %s) = %t", c.a, c.b, got)
+		}
+	}
+}
@@ -4,15 +4,22 @@
 package goarith
 
 import (
+	"errors"
 	"fmt"
 	"math"
 	"math/big"
 	"math/bits"
+	"reflect"
 	"strconv"
 	"strings"
+	"sync"
 )
 
 // Number is a general numeric type.
+// Its methods never modify the receiver or the arguments, so the same
+// *BigInt or *BigFloat can be passed as both, e.g. x.Mul(x).  A result
+// may be the receiver or an argument itself, e.g. x.Trunc() returns x
+// for a *BigInt x, so it must not be modified either.
 type Number interface {
 	// String returns a string representation of the number.
 	String() string
@@ -21,29 +28,171 @@ type Number interface {
 	// the int value represents this exactly.
 	Int() (i int, exact bool)
 
+	// Int64 returns the int64 value for this and a bool indicating
+	// whether the int64 value represents this exactly.
+	Int64() (i int64, exact bool)
+
+	// Int32 returns the int32 value of this truncated toward zero and a
+	// bool indicating whether the truncated value is in the range of
+	// int32, e.g. 2 and true for 2.5.  If it is out of the range, the
+	// int32 value is math.MinInt32 or math.MaxInt32.  If this is NaN,
+	// the int32 value is 0 and the bool is false.
+	Int32() (i int32, ok bool)
+
+	// Uint64 returns the uint64 value for this and a bool indicating
+	// whether the uint64 value represents this exactly.
+	// If this is negative or NaN, the uint64 value is 0.
+	// If this is too large, the uint64 value is math.MaxUint64.
+	Uint64() (u uint64, exact bool)
+
 	// Add adds this and b (i.e. it return this + b).
 	Add(b Number) Number
 
 	// Sub subtracts b from this (i.e. it returns this - b).
 	Sub(b Number) Number
 
+	// Inc and Dec return this + 1 and this - 1 respectively, promoting
+	// the result as Add and Sub do.
+	Inc() Number
+	Dec() Number
+
 	// Cmp compares this and b and returns:
 	//
 	// -1 if this <  b
 	//  0 if this == b
 	//  1 if this >  b
 	//
+	// The comparison is exact even between a large integer and a
+	// Float64.  Note that it returns 0 if this or b is NaN; see CmpTotal.
 	Cmp(b Number) int
 
+	// CmpAbs compares the absolute values of this and b and returns -1,
+	// 0 or 1 as Cmp does.  It returns 0 if this or b is NaN.
+	CmpAbs(b Number) int
+
 	// Mul multiplies this by b (i.e. it returns this * b).
 	Mul(b Number) Number
 
+	// Pow returns this raised to the power b.
+	// If this and b are integers and b >= 0, the result is exact and
+	// will be an Int32, Int64 or BigInt.  If this or b is a *BigFloat
+	// and b is an integer, the result will be a *BigFloat.  Otherwise
+	// the result will be a Float64 computed by math.Pow.
+	Pow(b Number) Number
+
+	// Log, Log2 and Log10 return the natural, binary and decimal
+	// logarithms of this respectively, computed by the math package.
+	// An integer is converted into float64 first, which may round it,
+	// but a *BigInt or *BigFloat beyond the range of float64 does not
+	// overflow.  As in the math package, the logarithm of zero is -Inf
+	// and that of a negative number is NaN.
+	Log() Float64
+	Log2() Float64
+	Log10() Float64
+
 	// RQuo returns the rounded quotient of this and b.
 	RQuo(b Number) Float64
 
+	// RQuoRat returns the exact quotient of this and b as a new big.Rat.
+	// A Float64 is converted exactly as ToBigRat does.
+	// It returns nil if this or b is infinite or NaN, or b is zero.
+	RQuoRat(b Number) *big.Rat
+
 	// QuoRem returns the quotient and the remainder of this and b.
-	// The quotient will be an Int32, Int64 or BigInt.
+	// The quotient will be an Int32, Int64 or BigInt, reduced to the
+	// narrowest of them, even if this or b is a Float64.  Only when the
+	// quotient is infinite or NaN (e.g. if b is 0.0), it will be a
+	// Float64.
 	QuoRem(b Number) (quotient Number, remainder Number)
+
+	// FloorDivMod is the same as QuoRem except that the quotient is
+	// rounded toward negative infinity and the remainder has the sign
+	// of b as Python's divmod does, e.g. -13 divmod 4 is -4 and 3.
+	FloorDivMod(b Number) (quotient Number, remainder Number)
+
+	// RQuoRound returns the quotient of this and b rounded to an integer
+	// according to mode.
+	// The result will be an Int32, Int64 or BigInt unless it is infinite
+	// or NaN.  If both this and b are integers, the result is exact.
+	RQuoRound(b Number, mode RoundingMode) Number
+
+	// IsInteger returns whether this has no fractional part.
+	// It returns false for infinity and NaN.
+	IsInteger() bool
+
+	// IsOne returns whether this == 1, i.e. whether this is the
+	// identity of Mul.
+	IsOne() bool
+
+	// IsInf reports whether this is an infinity, according to sign.
+	// If sign > 0, it reports whether this is positive infinity.
+	// If sign < 0, it reports whether this is negative infinity.
+	// If sign == 0, it reports whether this is either infinity.
+	// It always returns false for an integer.
+	IsInf(sign int) bool
+
+	// IsNaN reports whether this is NaN.
+	// It always returns false for an integer.
+	IsNaN() bool
+
+	// IsNegative and IsPositive report whether this < 0 and this > 0
+	// respectively.  Both return false for zeros, including -0.0, and
+	// for NaN.
+	IsNegative() bool
+	IsPositive() bool
+
+	// Signbit reports whether the sign bit of this is set, i.e. whether
+	// this < 0 or this is -0.0 or a NaN with the sign bit.
+	// For an integer, it is the same as IsNegative.
+	Signbit() bool
+
+	// QuantizeTo rounds this to the nearest multiple of step with ties
+	// to even.  The result will be a *BigFloat if this or step is a
+	// *BigFloat, a Float64 if this or step is a Float64, a *Decimal if
+	// this or step is a *Decimal, and an Int32, Int64 or BigInt
+	// otherwise.
+	// The rounding is exact unless the result is a Float64.
+	// If step is zero, the result is NaN if this or step is a Float64,
+	// and this as is otherwise.
+	QuantizeTo(step Number) Number
+
+	// Combine returns a hash value for the ordered pair of this and b.
+	// Pairs of equal values give the same hash value regardless of
+	// their concrete types.
+	Combine(b Number) uint64
+
+	// Reduce returns this in its narrowest integer representation,
+	// i.e. an Int64 or BigInt that fits in int32 as an Int32 and a
+	// BigInt that fits in int64 as an Int64.
+	// A Float64 is returned as is; see Float64.ReduceIntegral.
+	Reduce() Number
+
+	// CmpTotal compares this and b in a total order and returns -1, 0
+	// or 1 as Cmp does.  Unlike Cmp, it sorts NaN deterministically in
+	// the manner of IEEE 754 totalOrder:
+	//
+	// -NaN < -Inf < negative numbers < -0.0 < 0 < positive numbers < +Inf < +NaN
+	//
+	// where the sign of NaN is its sign bit, and 0 means every zero other
+	// than -0.0, i.e. +0.0 and the integer zeros.
+	CmpTotal(b Number) int
+
+	// QuoExact returns the quotient of this and b and true if b divides
+	// this exactly.  Otherwise it returns nil and false.
+	// The quotient will be an Int32, Int64 or BigInt unless it is
+	// infinite or NaN.
+	QuoExact(b Number) (quotient Number, exact bool)
+
+	// Trunc, Floor, Ceil and Round return this rounded to an integral
+	// value toward zero, toward -Inf, toward +Inf and to the nearest
+	// (ties away from zero) respectively.
+	// For an integer, they return this as is.
+	// For a Float64, they return a Float64; see Float64.ReduceIntegral
+	// to convert it into an integer.
+	Trunc() Number
+	Floor() Number
+	Ceil() Number
+	Round() Number
 }
 
 // Int32 implements Number.
@@ -75,7 +224,7 @@ func (a Int64) String() string {
 
 func (a Float64) String() string {
 	s := strconv.FormatFloat(float64(a), 'g', -1, 64)
-	if !strings.ContainsAny(s, ".e") {
+	if !strings.ContainsAny(s, ".eIN") { // not "+Inf", "-Inf" or "NaN"
 		s += ".0"
 	}
 	return s
@@ -86,9 +235,16 @@ func (a *BigInt) String() string {
 }
 
 // AsNumber converts a numeric value into a Number.
-// The numeric value may be int32, int64, int, float32, float64 or *big.Int.
-// For Int32, Int64, Float64 and *BigInt, it behaves as an identity function.
-// For the other types, it returns nil.
+// The numeric value may be int32, int64, int, uint32, uint64, uint,
+// float32, float64, *big.Int or *big.Float, or a string which
+// ParseDecimal accepts, e.g. "0.10", which is converted into a *Decimal.
+// A string without fractional digits, e.g. "12" or "1e3", is converted
+// into the narrowest integer instead.
+// An unsigned value beyond math.MaxInt64 is converted into a *BigInt.
+// For Int32, Int64, Float64, *BigInt, *BigFloat and *Decimal, it
+// behaves as an identity function.
+// For the other types and the strings which ParseDecimal rejects, it
+// returns nil.
 func AsNumber(a interface{}) Number {
 	switch x := a.(type) {
 	case Int32:
@@ -99,22 +255,159 @@ func AsNumber(a interface{}) Number {
 		return x
 	case *BigInt:
 		return x
+	case *BigFloat:
+		return x
+	case *Decimal:
+		return x
 	case int32:
 		return Int32(x)
 	case int64:
 		return Int64(x).reduce()
 	case int:
 		return Int64(x).reduce()
+	case uint32:
+		return Int64(x).reduce()
+	case uint64:
+		return fromUint64(x)
+	case uint:
+		return fromUint64(uint64(x))
 	case float32:
 		return Float64(x)
 	case float64:
 		return Float64(x)
 	case *big.Int:
 		return (*BigInt)(x).reduce()
+	case *big.Float:
+		return (*BigFloat)(x)
+	case string:
+		if d, err := ParseDecimal(x); err == nil {
+			return d.reduceScale()
+		}
 	}
 	return nil
 }
 
+// fromUint64 converts u into the narrowest Number.
+func fromUint64(u uint64) Number {
+	if u <= math.MaxInt64 {
+		return Int64(u).reduce()
+	}
+	return (*BigInt)(new(big.Int).SetUint64(u))
+}
+
+// AsNumbers converts each of vals into a Number by AsNumber.
+// If some value cannot be converted, it returns an error which
+// reports the index of the first such value.
+func AsNumbers(vals ...interface{}) ([]Number, error) {
+	result := make([]Number, len(vals))
+	for i, v := range vals {
+		n := AsNumber(v)
+		if n == nil {
+			return nil, fmt.Errorf("goarith: unsupported value %v (%T) at index %d", v, v, i)
+		}
+		result[i] = n
+	}
+	return result, nil
+}
+
+// AsNumberSlice converts each element of the slice xs into a Number by
+// AsNumber.  The slice may be []int, []int32, []int64, []uint, []uint32,
+// []uint64, []float32, []float64, []*big.Int, []*big.Float, []string,
+// []Number or []interface{} holding such values.  It returns an error if xs is not a slice or
+// some element cannot be converted.
+// Unlike AsNumbers, it takes a whole slice instead of variadic values.
+func AsNumberSlice(xs interface{}) ([]Number, error) {
+	v := reflect.ValueOf(xs)
+	if v.Kind() != reflect.Slice {
+		return nil, fmt.Errorf("goarith: not a slice: %T", xs)
+	}
+	result := make([]Number, v.Len())
+	for i := range result {
+		e := v.Index(i).Interface()
+		n := AsNumber(e)
+		if n == nil {
+			return nil, fmt.Errorf("goarith: unsupported value %v (%T) at index %d", e, e, i)
+		}
+		result[i] = n
+	}
+	return result, nil
+}
+
+// Kind returns a stable name of the representation of n: "int32",
+// "int64", "float64", "bigint", "bigfloat" or "decimal".
+func Kind(n Number) string {
+	switch n.(type) {
+	case Int32:
+		return "int32"
+	case Int64:
+		return "int64"
+	case Float64:
+		return "float64"
+	case *BigInt:
+		return "bigint"
+	case *BigFloat:
+		return "bigfloat"
+	case *Decimal:
+		return "decimal"
+	}
+	panic(fmt.Sprintf("Kind(%s)", n.String()))
+}
+
+// identity returns v in the same concrete type as like.  A *BigFloat
+// result has the same precision as like, and a *Decimal result has the
+// same scale as like if it is positive.
+func identity(like Number, v int64) Number {
+	switch x := like.(type) {
+	case Int32:
+		return Int32(v)
+	case Int64:
+		return Int64(v)
+	case Float64:
+		return Float64(v)
+	case *BigInt:
+		return (*BigInt)(big.NewInt(v))
+	case *BigFloat:
+		z := new(big.Float).SetPrec((*big.Float)(x).Prec())
+		return (*BigFloat)(z.SetInt64(v))
+	case *Decimal:
+		d := &Decimal{big.NewInt(v), 0}
+		if x.scale > 0 {
+			d = d.roundTo(x.scale, ToZero) // only rescales d.
+		}
+		return d
+	}
+	panic(fmt.Sprintf("identity(%s)", like.String()))
+}
+
+// Zero returns 0 in the same concrete type as like, e.g. Float64(0) for
+// a Float64.  For a *BigInt, it returns a new unreduced *BigInt.
+// For a *BigFloat, it returns a new *BigFloat of the same precision.
+// For a *Decimal of a positive scale, it returns a *Decimal of the same
+// scale, e.g. 0.00 for 1.25.
+func Zero(like Number) Number {
+	return identity(like, 0)
+}
+
+// One returns 1 in the same concrete type as like as Zero does 0.
+func One(like Number) Number {
+	return identity(like, 1)
+}
+
+// NewBigInt parses s as a decimal integer with an optional sign and
+// returns it as the narrowest of Int32, Int64 and *BigInt.
+func NewBigInt(s string) (Number, error) {
+	if !isDecimalInteger(s) {
+		return nil, parseError("NewBigInt", s, strconv.ErrSyntax)
+	}
+	z, _ := new(big.Int).SetString(s, 10)
+	return (*BigInt)(z).reduce(), nil
+}
+
+// NewBigIntFromInt64 returns i as the narrowest of Int32 and Int64.
+func NewBigIntFromInt64(i int64) Number {
+	return Int64(i).reduce()
+}
+
 // Int methods
 
 func (a Int32) Int() (int, bool) {
@@ -149,8 +442,337 @@ func (a *BigInt) Int() (int, bool) {
 	}
 }
 
+// ErrRange is returned by IntErr and ToBytes when the value is out of
+// the range of the destination.
+var ErrRange = errors.New("goarith: value out of range")
+
+// intErr returns i and nil if exact, or i and ErrRange otherwise.
+func intErr(i int, exact bool) (int, error) {
+	if !exact {
+		return i, ErrRange
+	}
+	return i, nil
+}
+
+// IntErr returns the int value of a.  If a is out of the range of int,
+// it returns the value clamped as Int does and ErrRange, as
+// strconv.ParseInt does.
+func (a Int64) IntErr() (int, error) {
+	return intErr(a.Int())
+}
+
+// IntErr returns the int value of a.  If a is out of the range of int,
+// it returns the value clamped as Int does and ErrRange, as
+// strconv.ParseInt does.
+func (a *BigInt) IntErr() (int, error) {
+	return intErr(a.Int())
+}
+
+// Int64 methods
+
+func (a Int32) Int64() (int64, bool) {
+	return int64(a), true
+}
+
+func (a Int64) Int64() (int64, bool) {
+	return int64(a), true
+}
+
+func (a Float64) Int64() (int64, bool) {
+	f := float64(a)
+	if math.IsNaN(f) {
+		return 0, false
+	} else if f < math.MinInt64 {
+		return math.MinInt64, false
+	} else if f >= -math.MinInt64 { // f >= 2**63
+		return math.MaxInt64, false
+	}
+	i := int64(f)
+	return i, float64(i) == f
+}
+
+func (a *BigInt) Int64() (int64, bool) {
+	x := (*big.Int)(a)
+	if x.IsInt64() {
+		return x.Int64(), true
+	} else if x.Sign() < 0 {
+		return math.MinInt64, false
+	} else {
+		return math.MaxInt64, false
+	}
+}
+
+// Int32 methods
+
+// toInt32 returns i clamped to the range of int32 and whether i is in
+// the range and ok is true.
+func toInt32(i int64, ok bool) (int32, bool) {
+	if i < math.MinInt32 {
+		return math.MinInt32, false
+	} else if i > math.MaxInt32 {
+		return math.MaxInt32, false
+	}
+	return int32(i), ok
+}
+
+func (a Int32) Int32() (int32, bool) {
+	return int32(a), true
+}
+
+func (a Int64) Int32() (int32, bool) {
+	return toInt32(int64(a), true)
+}
+
+func (a Float64) Int32() (int32, bool) {
+	return toInt32(a.Trunc().Int64())
+}
+
+func (a *BigInt) Int32() (int32, bool) {
+	return toInt32(a.Int64())
+}
+
+// Uint64 methods
+
+func (a Int32) Uint64() (uint64, bool) {
+	if a < 0 {
+		return 0, false
+	}
+	return uint64(a), true
+}
+
+func (a Int64) Uint64() (uint64, bool) {
+	if a < 0 {
+		return 0, false
+	}
+	return uint64(a), true
+}
+
+func (a Float64) Uint64() (uint64, bool) {
+	f := float64(a)
+	if math.IsNaN(f) || f < 0 {
+		return 0, false
+	} else if f >= math.MaxUint64 { // f >= 2**64
+		return math.MaxUint64, false
+	}
+	u := uint64(f)
+	return u, float64(u) == f
+}
+
+func (a *BigInt) Uint64() (uint64, bool) {
+	x := (*big.Int)(a)
+	if x.IsUint64() {
+		return x.Uint64(), true
+	} else if x.Sign() < 0 {
+		return 0, false
+	} else {
+		return math.MaxUint64, false
+	}
+}
+
 // Utilities
 
+var bigOne = big.NewInt(1)
+
+// toBigInt returns a new big.Int for an Int32, Int64 or *BigInt.
+// For the other types, it returns nil.
+func toBigInt(n Number) *big.Int {
+	switch x := n.(type) {
+	case Int32:
+		return big.NewInt(int64(x))
+	case Int64:
+		return big.NewInt(int64(x))
+	case *BigInt:
+		return new(big.Int).Set((*big.Int)(x))
+	}
+	return nil
+}
+
+// integerValue returns the value of n as a new big.Int if n is an
+// integer or an integral Float64, *BigFloat or *Decimal.  Otherwise it
+// returns nil.
+func integerValue(n Number) *big.Int {
+	if z := toBigInt(n); z != nil {
+		return z
+	} else if n.IsInteger() {
+		return ToBigRat(n).Num()
+	}
+	return nil
+}
+
+// asFloat64 converts n into a Float64.
+func asFloat64(n Number) Float64 {
+	switch x := n.(type) {
+	case Int32:
+		return Float64(x)
+	case Int64:
+		return Float64(x)
+	case Float64:
+		return x
+	case *BigInt:
+		return x.toFloat64()
+	case *BigFloat:
+		f, _ := (*big.Float)(x).Float64()
+		return Float64(f)
+	case *Decimal:
+		f, _ := x.rat().Float64()
+		return Float64(f)
+	}
+	panic(fmt.Sprintf("asFloat64(%s)", n.String()))
+}
+
+// Float32 returns the float32 nearest to n and whether it represents n
+// exactly.  The conversion is inexact if n overflows float32 to an
+// infinity or needs more than the 24-bit significand of float32, e.g.
+// for Int64(1<<24 + 1), while Int64(1<<30) is exact.  An infinity and NaN
+// are converted into the same in float32 exactly.
+func Float32(n Number) (f float32, exact bool) {
+	if x, ok := n.(Float64); ok {
+		f = float32(x)
+		return f, float64(f) == float64(x) || math.IsNaN(float64(x))
+	}
+	if r := ToBigRat(n); r != nil {
+		return r.Float32()
+	}
+	return float32(asFloat64(n)), true // *BigFloat infinity
+}
+
+// ToComplex128 converts n into a complex128 whose real part is n and
+// whose imaginary part is 0.  The real part is rounded to the nearest
+// float64 if n is not exactly representable, e.g. an integer beyond
+// 2**53 or a *BigFloat with a higher precision.
+func ToComplex128(n Number) complex128 {
+	return complex(float64(asFloat64(n)), 0)
+}
+
+// ToBigRat converts n into a new big.Rat exactly; e.g. it converts
+// Float64(0.5) into 1/2.  If n is infinite or NaN, it returns nil.
+func ToBigRat(n Number) *big.Rat {
+	switch x := n.(type) {
+	case Int32:
+		return new(big.Rat).SetInt64(int64(x))
+	case Int64:
+		return new(big.Rat).SetInt64(int64(x))
+	case Float64:
+		return new(big.Rat).SetFloat64(float64(x))
+	case *BigInt:
+		return new(big.Rat).SetInt((*big.Int)(x))
+	case *BigFloat:
+		r, _ := (*big.Float)(x).Rat(nil)
+		return r
+	case *Decimal:
+		return x.rat()
+	}
+	panic(fmt.Sprintf("ToBigRat(%s)", n.String()))
+}
+
+// Num returns the numerator of n written as a reduced fraction with a
+// positive denominator; e.g. it returns 5 for Int64(5) and 3 for
+// Float64(0.75).  If n is infinite or NaN, it returns nil.
+func Num(n Number) *big.Int {
+	if r := ToBigRat(n); r != nil {
+		return r.Num()
+	}
+	return nil
+}
+
+// Denom returns the denominator of n written as a reduced fraction; it
+// is always positive, e.g. 1 for any integer and 4 for Float64(0.75).
+// If n is infinite or NaN, it returns nil.
+func Denom(n Number) *big.Int {
+	if r := ToBigRat(n); r != nil {
+		return r.Denom()
+	}
+	return nil
+}
+
+// Simplify returns the fraction num/den in lowest terms, i.e. num and den
+// divided by their greatest common divisor, with the sign normalized so
+// that the denominator is positive, e.g. -3 and 2 for 6 and -4, and 0
+// and 1 for 0 and 5.  Both results are reduced to the narrowest integer
+// types.  An integral Float64, *BigFloat or *Decimal is taken as the
+// equal integer, e.g. 2 and 1 for Float64(4) and Float64(2).  It returns
+// an error if num or den is not an integral value or den is zero.
+func Simplify(num, den Number) (Number, Number, error) {
+	n, d := integerValue(num), integerValue(den)
+	if n == nil || d == nil {
+		return nil, nil, fmt.Errorf("goarith: non-integral value in %s/%s", num.String(), den.String())
+	} else if d.Sign() == 0 {
+		return nil, nil, fmt.Errorf("goarith: zero denominator in %s/%s", num.String(), den.String())
+	}
+	r := new(big.Rat).SetFrac(n, d) // SetFrac normalizes the fraction.
+	return (*BigInt)(r.Num()).reduce(), (*BigInt)(r.Denom()).reduce(), nil
+}
+
+// Rationalize returns the best rational approximation num/den of f with
+// 0 < den <= maxDenom, i.e. the fraction closest to f among those, e.g.
+// 1/3 for 0.333333333 and maxDenom 100.  It walks the convergents of the
+// continued fraction of the exact value of f and finally tries the best
+// semiconvergent, as in the Stern-Brocot tree.  Of two equally close
+// fractions, it returns the one with the smaller denominator.
+// It panics if maxDenom < 1, f is infinite or NaN, or num overflows
+// int64.
+func Rationalize(f Float64, maxDenom int64) (num, den int64) {
+	r := ToBigRat(f)
+	if maxDenom < 1 || r == nil {
+		panic(fmt.Sprintf("Rationalize(%s, %d)", f.String(), maxDenom))
+	}
+	max := big.NewInt(maxDenom)
+	if r.Denom().Cmp(max) <= 0 {
+		return ratInt64(r)
+	}
+	p0, q0, p1, q1 := big.NewInt(0), big.NewInt(1), big.NewInt(1), big.NewInt(0)
+	n, d := new(big.Int).Set(r.Num()), new(big.Int).Set(r.Denom())
+	a, t := new(big.Int), new(big.Int)
+	for {
+		a.Div(n, d) // floor(n / d) for d > 0
+		q2 := new(big.Int).Add(q0, t.Mul(a, q1))
+		if q2.Cmp(max) > 0 {
+			break
+		}
+		p0, q0, p1, q1 = p1, q1, p0.Add(p0, t.Mul(a, p1)), q2
+		n, d = d, n.Sub(n, t.Mul(a, d))
+	}
+	// p1/q1 is the last convergent; (p0 + k*p1)/(q0 + k*q1) is the
+	// best semiconvergent within maxDenom.
+	k := t.Div(t.Sub(max, q0), q1)
+	s := new(big.Rat).SetFrac(p0.Add(p0, new(big.Int).Mul(k, p1)), q0.Add(q0, k.Mul(k, q1)))
+	c := new(big.Rat).SetFrac(p1, q1)
+	ds := new(big.Rat).Sub(s, r)
+	dc := new(big.Rat).Sub(c, r)
+	switch dc.Abs(dc).Cmp(ds.Abs(ds)) {
+	case -1:
+		return ratInt64(c)
+	case 1:
+		return ratInt64(s)
+	}
+	// A tie: prefer the smaller denominator, which is s when k is 0.
+	if s.Denom().Cmp(c.Denom()) < 0 {
+		return ratInt64(s)
+	}
+	return ratInt64(c)
+}
+
+// ratInt64 returns the numerator and the denominator of r as int64.
+// It panics if the numerator overflows int64.
+func ratInt64(r *big.Rat) (int64, int64) {
+	if !r.Num().IsInt64() {
+		panic(fmt.Sprintf("Rationalize: %s overflows int64", r.Num().String()))
+	}
+	return r.Num().Int64(), r.Denom().Int64()
+}
+
+// fromIntegral converts an integral float64 into an Int32, Int64 or
+// *BigInt.  If f is infinite or NaN, it returns f as a Float64.
+func fromIntegral(f float64) Number {
+	if math.IsInf(f, 0) || math.IsNaN(f) {
+		return Float64(f)
+	} else if -1<<63 <= f && f < 1<<63 {
+		return Int64(f).reduce()
+	}
+	z, _ := new(big.Float).SetFloat64(f).Int(nil)
+	return (*BigInt)(z)
+}
+
 func (a *BigInt) toFloat64() Float64 {
 	z := new(big.Rat).SetInt((*big.Int)(a))
 	f, _ := z.Float64() // f may be infinity.
@@ -172,12 +794,43 @@ func (a *BigInt) reduce() Number {
 	return a
 }
 
+// OnPromote, if not nil, is called with the names of two types, e.g.
+// "Int32" and "Int64", whenever Add, Sub, Mul, Inc or Dec of Int32 and
+// Int64 operands overflows the wider type of them and promotes the
+// result to the next one, i.e. from Int32 to Int64 or from Int64 to
+// *BigInt ("BigInt").  It is a debugging aid to find where values grow
+// unexpectedly.  It is not safe to set OnPromote concurrently with
+// operations.
+var OnPromote func(from, to string)
+
+// promote32 calls OnPromote if r, the result of an operation of Int32
+// operands, is an Int64.  It returns r.
+func promote32(r Number) Number {
+	if OnPromote != nil {
+		if _, ok := r.(Int64); ok {
+			OnPromote("Int32", "Int64")
+		}
+	}
+	return r
+}
+
+// promote64 calls OnPromote if r, the result of an operation of Int64
+// operands, is a *BigInt.  It returns r.
+func promote64(r Number) Number {
+	if OnPromote != nil {
+		if _, ok := r.(*BigInt); ok {
+			OnPromote("Int64", "BigInt")
+		}
+	}
+	return r
+}
+
 func (a Int64) addInt64(b Int64) Number {
 	c := a + b
 	if (a >= 0 && b >= 0 && c < 0) || (a < 0 && b < 0 && c >= 0) { // overflow
 		z := big.NewInt(int64(a))
 		z.Add(z, big.NewInt(int64(b)))
-		return (*BigInt)(z)
+		return promote64((*BigInt)(z))
 	}
 	return c.reduce()
 }
@@ -198,7 +851,7 @@ func (a Int64) subInt64(b Int64) Number {
 	}
 	z := big.NewInt(int64(a))
 	z.Sub(z, big.NewInt(int64(b)))
-	return (*BigInt)(z)
+	return promote64((*BigInt)(z))
 }
 
 func (a *BigInt) subBigInt(b *big.Int) Number {
@@ -207,6 +860,24 @@ func (a *BigInt) subBigInt(b *big.Int) Number {
 	return (*BigInt)(z).reduce()
 }
 
+// absInt64 returns |x| as a uint64, which holds |math.MinInt64| exactly.
+func absInt64(x int64) uint64 {
+	if x < 0 {
+		return -uint64(x)
+	}
+	return uint64(x)
+}
+
+func cmpUint64(a, b uint64) int {
+	if a < b {
+		return -1
+	} else if a > b {
+		return 1
+	} else {
+		return 0
+	}
+}
+
 func (a Int64) cmpInt64(b Int64) int {
 	if a < b {
 		return -1
@@ -227,10 +898,39 @@ func (a Float64) cmpFloat64(b Float64) int {
 	}
 }
 
+// cmpInt64Float64 compares i and f exactly, even if |i| > 2**53 and
+// float64(i) is inexact.  It returns 0 if f is NaN.
+func cmpInt64Float64(i int64, f float64) int {
+	if math.IsNaN(f) {
+		return 0
+	} else if f >= 1<<63 {
+		return -1
+	} else if f < -1<<63 {
+		return 1
+	}
+	t := math.Trunc(f)
+	if c := Int64(i).cmpInt64(Int64(t)); c != 0 {
+		return c
+	}
+	return Float64(t).cmpFloat64(Float64(f)) // by the fractional part
+}
+
+// cmpBigIntFloat64 compares x and f exactly.  It returns 0 if f is NaN.
+func cmpBigIntFloat64(x *big.Int, f float64) int {
+	if x.IsInt64() {
+		return cmpInt64Float64(x.Int64(), f)
+	} else if math.IsNaN(f) {
+		return 0
+	} else if math.IsInf(f, 0) {
+		return -int(math.Copysign(1, f))
+	}
+	return new(big.Float).SetInt(x).Cmp(big.NewFloat(f))
+}
+
 func (a Int64) mulInt64(b Int64) Number {
 	z := big.NewInt(int64(a))
 	z.Mul(z, big.NewInt(int64(b)))
-	return (*BigInt)(z).reduce()
+	return promote64((*BigInt)(z).reduce())
 }
 
 func (a *BigInt) mulBigInt(b *big.Int) Number {
@@ -243,17 +943,43 @@ func (a Int64) quoRemInt64(b Int64) (Number, Number) {
 	return (a / b).reduce(), (a % b).reduce()
 }
 
+// quoRemFloat64 returns the truncated quotient as an Int32, Int64 or
+// *BigInt and the remainder as a Float64.  The quotient is a Float64
+// only if it is infinite or NaN.
 func (a Float64) quoRemFloat64(b Float64) (Number, Float64) {
 	q := math.Trunc(float64(a) / float64(b))
 	r := math.Mod(float64(a), float64(b))
-	if !math.IsInf(q, 0) && !math.IsNaN(q) {
-		s := fmt.Sprintf("%.0f", q)
-		z := new(big.Int)
-		if _, ok := z.SetString(s, 10); ok {
-			return (*BigInt)(z).reduce(), Float64(r)
-		}
+	return fromIntegral(q), Float64(r)
+}
+
+// scratchPool holds arrays of big.Int to hold temporary values such as
+// remainders and Int32 and Int64 operands.
+var scratchPool = sync.Pool{
+	New: func() interface{} { return new([3]big.Int) },
+}
+
+// divExact returns x / y and true if y divides x exactly.
+// Otherwise it returns nil and false.
+// It rejects some cases by the number of trailing zero bits and the
+// magnitude before computing the quotient, and keeps the remainder in a
+// scratch big.Int instead of allocating it.
+func divExact(x, y *big.Int) (*big.Int, bool) {
+	if y.Sign() == 0 {
+		panic("division by zero")
+	} else if x.Sign() == 0 {
+		return new(big.Int), true
+	} else if x.TrailingZeroBits() < y.TrailingZeroBits() {
+		return nil, false
+	} else if x.CmpAbs(y) < 0 {
+		return nil, false
+	}
+	s := scratchPool.Get().(*[3]big.Int)
+	defer scratchPool.Put(s)
+	q, r := new(big.Int), &s[0]
+	if q.QuoRem(x, y, r); r.Sign() != 0 {
+		return nil, false
 	}
-	return Float64(q), Float64(r)
+	return q, true
 }
 
 func (a *BigInt) quoRemBigInt(b *big.Int) (Number, Number) {
@@ -268,7 +994,7 @@ func (a *BigInt) quoRemBigInt(b *big.Int) (Number, Number) {
 func (a Int32) Add(b Number) Number {
 	switch y := b.(type) {
 	case Int32:
-		return (Int64(a) + Int64(y)).reduce()
+		return promote32((Int64(a) + Int64(y)).reduce())
 	case Int64:
 		return Int64(a).addInt64(y)
 	case Float64:
@@ -277,6 +1003,10 @@ func (a Int32) Add(b Number) Number {
 		x := big.NewInt(int64(a))
 		x.Add(x, (*big.Int)(y))
 		return (*BigInt)(x).reduce()
+	case *BigFloat:
+		return bigFloatOp(a, y, (*big.Float).Add)
+	case *Decimal:
+		return decimalOp(a, y, addDecimal, Number.Add)
 	}
 	panic(fmt.Sprintf("%s.Add(%s)", a.String(), b.String()))
 }
@@ -293,6 +1023,10 @@ func (a Int64) Add(b Number) Number {
 		x := big.NewInt(int64(a))
 		x.Add(x, (*big.Int)(y))
 		return (*BigInt)(x).reduce()
+	case *BigFloat:
+		return bigFloatOp(a, y, (*big.Float).Add)
+	case *Decimal:
+		return decimalOp(a, y, addDecimal, Number.Add)
 	}
 	panic(fmt.Sprintf("%s.Add(%s)", a.String(), b.String()))
 }
@@ -307,10 +1041,20 @@ func (a Float64) Add(b Number) Number {
 		return a + y
 	case *BigInt:
 		return a + y.toFloat64()
+	case *BigFloat:
+		return bigFloatOp(a, y, (*big.Float).Add)
+	case *Decimal:
+		return decimalOp(a, y, addDecimal, Number.Add)
 	}
 	panic(fmt.Sprintf("%s.Add(%s)", a.String(), b.String()))
 }
 
+// AddF returns a + b.  Unlike Add, it takes a Float64 directly and
+// skips the type switch on b, for inner loops of homogeneous floats.
+func (a Float64) AddF(b Float64) Float64 {
+	return a + b
+}
+
 func (a *BigInt) Add(b Number) Number {
 	switch y := b.(type) {
 	case Int32:
@@ -321,16 +1065,43 @@ func (a *BigInt) Add(b Number) Number {
 		return a.toFloat64() + y
 	case *BigInt:
 		return a.addBigInt((*big.Int)(y))
+	case *BigFloat:
+		return bigFloatOp(a, y, (*big.Float).Add)
+	case *Decimal:
+		return decimalOp(a, y, addDecimal, Number.Add)
 	}
 	panic(fmt.Sprintf("%s.Add(%s)", a.String(), b.String()))
 }
 
+// AddInPlace sets a to a + b and returns a.  Unlike Add, it mutates a
+// and does not reduce it, so that a loop accumulating into an owned
+// *BigInt does not allocate a new result for each sum.  The caller must
+// not use AddInPlace on a *BigInt shared with others.  Note that any
+// Number obtained from a, e.g. a.Trunc(), Clamp(a, lo, hi) or a value
+// added to a KahanSum, may be a itself and changes with it.
+// It panics if b is not an Int32, Int64 or *BigInt.
+func (a *BigInt) AddInPlace(b Number) *BigInt {
+	x := (*big.Int)(a)
+	var t big.Int
+	switch y := b.(type) {
+	case Int32:
+		x.Add(x, t.SetInt64(int64(y)))
+	case Int64:
+		x.Add(x, t.SetInt64(int64(y)))
+	case *BigInt:
+		x.Add(x, (*big.Int)(y))
+	default:
+		panic(fmt.Sprintf("%s.AddInPlace(%s)", a.String(), b.String()))
+	}
+	return a
+}
+
 // Sub methods
 
 func (a Int32) Sub(b Number) Number {
 	switch y := b.(type) {
 	case Int32:
-		return (Int64(a) - Int64(y)).reduce()
+		return promote32((Int64(a) - Int64(y)).reduce())
 	case Int64:
 		return Int64(a).subInt64(y)
 	case Float64:
@@ -339,6 +1110,10 @@ func (a Int32) Sub(b Number) Number {
 		x := big.NewInt(int64(a))
 		x.Sub(x, (*big.Int)(y))
 		return (*BigInt)(x).reduce()
+	case *BigFloat:
+		return bigFloatOp(a, y, (*big.Float).Sub)
+	case *Decimal:
+		return decimalOp(a, y, subDecimal, Number.Sub)
 	}
 	panic(fmt.Sprintf("%s.Sub(%s)", a.String(), b.String()))
 }
@@ -355,6 +1130,10 @@ func (a Int64) Sub(b Number) Number {
 		x := big.NewInt(int64(a))
 		x.Sub(x, (*big.Int)(y))
 		return (*BigInt)(x).reduce()
+	case *BigFloat:
+		return bigFloatOp(a, y, (*big.Float).Sub)
+	case *Decimal:
+		return decimalOp(a, y, subDecimal, Number.Sub)
 	}
 	panic(fmt.Sprintf("%s.Sub(%s)", a.String(), b.String()))
 }
@@ -369,10 +1148,19 @@ func (a Float64) Sub(b Number) Number {
 		return a - y
 	case *BigInt:
 		return a - y.toFloat64()
+	case *BigFloat:
+		return bigFloatOp(a, y, (*big.Float).Sub)
+	case *Decimal:
+		return decimalOp(a, y, subDecimal, Number.Sub)
 	}
 	panic(fmt.Sprintf("%s.Sub(%s)", a.String(), b.String()))
 }
 
+// SubF returns a - b.  See AddF.
+func (a Float64) SubF(b Float64) Float64 {
+	return a - b
+}
+
 func (a *BigInt) Sub(b Number) Number {
 	switch y := b.(type) {
 	case Int32:
@@ -383,10 +1171,62 @@ func (a *BigInt) Sub(b Number) Number {
 		return a.toFloat64() - y
 	case *BigInt:
 		return a.subBigInt((*big.Int)(y))
+	case *BigFloat:
+		return bigFloatOp(a, y, (*big.Float).Sub)
+	case *Decimal:
+		return decimalOp(a, y, subDecimal, Number.Sub)
 	}
 	panic(fmt.Sprintf("%s.Sub(%s)", a.String(), b.String()))
 }
 
+// Inc methods
+
+func (a Int32) Inc() Number {
+	if a < math.MaxInt32 {
+		return a + 1
+	}
+	return promote32(Int64(a) + 1)
+}
+
+func (a Int64) Inc() Number {
+	if a < math.MaxInt64 {
+		return (a + 1).reduce()
+	}
+	return a.addInt64(1)
+}
+
+func (a Float64) Inc() Number {
+	return a + 1
+}
+
+func (a *BigInt) Inc() Number {
+	return a.addBigInt(bigOne)
+}
+
+// Dec methods
+
+func (a Int32) Dec() Number {
+	if a > math.MinInt32 {
+		return a - 1
+	}
+	return promote32(Int64(a) - 1)
+}
+
+func (a Int64) Dec() Number {
+	if a > math.MinInt64 {
+		return (a - 1).reduce()
+	}
+	return a.addInt64(-1)
+}
+
+func (a Float64) Dec() Number {
+	return a - 1
+}
+
+func (a *BigInt) Dec() Number {
+	return a.subBigInt(bigOne)
+}
+
 // Cmp methods
 
 func (a Int32) Cmp(b Number) int {
@@ -406,6 +1246,10 @@ func (a Int32) Cmp(b Number) int {
 	case *BigInt:
 		x := big.NewInt(int64(a))
 		return x.Cmp((*big.Int)(y))
+	case *BigFloat:
+		return cmpBigFloat(a, y)
+	case *Decimal:
+		return cmpDecimal(a, y)
 	}
 	panic(fmt.Sprintf("%s.Cmp(%s)", a.String(), b.String()))
 }
@@ -417,10 +1261,14 @@ func (a Int64) Cmp(b Number) int {
 	case Int64:
 		return a.cmpInt64(y)
 	case Float64:
-		return Float64(a).cmpFloat64(y)
+		return cmpInt64Float64(int64(a), float64(y))
 	case *BigInt:
 		x := big.NewInt(int64(a))
 		return x.Cmp((*big.Int)(y))
+	case *BigFloat:
+		return cmpBigFloat(a, y)
+	case *Decimal:
+		return cmpDecimal(a, y)
 	}
 	panic(fmt.Sprintf("%s.Cmp(%s)", a.String(), b.String()))
 }
@@ -430,11 +1278,15 @@ func (a Float64) Cmp(b Number) int {
 	case Int32:
 		return a.cmpFloat64(Float64(y))
 	case Int64:
-		return a.cmpFloat64(Float64(y))
+		return -cmpInt64Float64(int64(y), float64(a))
 	case Float64:
 		return a.cmpFloat64(y)
 	case *BigInt:
-		return a.cmpFloat64(y.toFloat64())
+		return -cmpBigIntFloat64((*big.Int)(y), float64(a))
+	case *BigFloat:
+		return cmpBigFloat(a, y)
+	case *Decimal:
+		return cmpDecimal(a, y)
 	}
 	panic(fmt.Sprintf("%s.Cmp(%s)", a.String(), b.String()))
 }
@@ -446,19 +1298,88 @@ func (a *BigInt) Cmp(b Number) int {
 	case Int64:
 		return (*big.Int)(a).Cmp(big.NewInt(int64(y)))
 	case Float64:
-		return a.toFloat64().cmpFloat64(y)
+		return cmpBigIntFloat64((*big.Int)(a), float64(y))
 	case *BigInt:
 		return (*big.Int)(a).Cmp((*big.Int)(y))
+	case *BigFloat:
+		return cmpBigFloat(a, y)
+	case *Decimal:
+		return cmpDecimal(a, y)
 	}
 	panic(fmt.Sprintf("%s.Cmp(%s)", a.String(), b.String()))
 }
 
+// CmpAbs methods
+
+func (a Int32) CmpAbs(b Number) int {
+	return Int64(a).CmpAbs(b)
+}
+
+func (a Int64) CmpAbs(b Number) int {
+	switch y := b.(type) {
+	case Int32:
+		return cmpUint64(absInt64(int64(a)), absInt64(int64(y)))
+	case Int64:
+		return cmpUint64(absInt64(int64(a)), absInt64(int64(y)))
+	case Float64:
+		return -y.CmpAbs(a)
+	case *BigInt:
+		x := big.NewInt(int64(a))
+		return x.CmpAbs((*big.Int)(y))
+	case *BigFloat:
+		return cmpAbsBigFloat(a, y)
+	case *Decimal:
+		return cmpAbsDecimal(a, y)
+	}
+	panic(fmt.Sprintf("%s.CmpAbs(%s)", a.String(), b.String()))
+}
+
+func (a Float64) CmpAbs(b Number) int {
+	x := Float64(math.Abs(float64(a)))
+	switch y := b.(type) {
+	case Int32:
+		return -cmpInt64Float64(int64(absInt64(int64(y))), float64(x))
+	case Int64:
+		if y == math.MinInt64 {
+			return -cmpBigIntFloat64(new(big.Int).Abs(big.NewInt(int64(y))), float64(x))
+		}
+		return -cmpInt64Float64(int64(absInt64(int64(y))), float64(x))
+	case Float64:
+		return x.cmpFloat64(Float64(math.Abs(float64(y))))
+	case *BigInt:
+		return -cmpBigIntFloat64(new(big.Int).Abs((*big.Int)(y)), float64(x))
+	case *BigFloat:
+		return cmpAbsBigFloat(a, y)
+	case *Decimal:
+		return cmpAbsDecimal(a, y)
+	}
+	panic(fmt.Sprintf("%s.CmpAbs(%s)", a.String(), b.String()))
+}
+
+func (a *BigInt) CmpAbs(b Number) int {
+	switch y := b.(type) {
+	case Int32:
+		return (*big.Int)(a).CmpAbs(big.NewInt(int64(y)))
+	case Int64:
+		return (*big.Int)(a).CmpAbs(big.NewInt(int64(y)))
+	case Float64:
+		return -y.CmpAbs(a)
+	case *BigInt:
+		return (*big.Int)(a).CmpAbs((*big.Int)(y))
+	case *BigFloat:
+		return cmpAbsBigFloat(a, y)
+	case *Decimal:
+		return cmpAbsDecimal(a, y)
+	}
+	panic(fmt.Sprintf("%s.CmpAbs(%s)", a.String(), b.String()))
+}
+
 // Mul methods
 
 func (a Int32) Mul(b Number) Number {
 	switch y := b.(type) {
 	case Int32:
-		return (Int64(a) * Int64(y)).reduce()
+		return promote32((Int64(a) * Int64(y)).reduce())
 	case Int64:
 		return Int64(a).mulInt64(y)
 	case Float64:
@@ -467,6 +1388,10 @@ func (a Int32) Mul(b Number) Number {
 		x := big.NewInt(int64(a))
 		x.Mul(x, (*big.Int)(y))
 		return (*BigInt)(x).reduce()
+	case *BigFloat:
+		return bigFloatOp(a, y, (*big.Float).Mul)
+	case *Decimal:
+		return decimalOp(a, y, mulDecimal, Number.Mul)
 	}
 	panic(fmt.Sprintf("%s.Mul(%s)", a.String(), b.String()))
 }
@@ -483,6 +1408,10 @@ func (a Int64) Mul(b Number) Number {
 		x := big.NewInt(int64(a))
 		x.Mul(x, (*big.Int)(y))
 		return (*BigInt)(x).reduce()
+	case *BigFloat:
+		return bigFloatOp(a, y, (*big.Float).Mul)
+	case *Decimal:
+		return decimalOp(a, y, mulDecimal, Number.Mul)
 	}
 	panic(fmt.Sprintf("%s.Mul(%s)", a.String(), b.String()))
 }
@@ -497,10 +1426,19 @@ func (a Float64) Mul(b Number) Number {
 		return a * y
 	case *BigInt:
 		return a * y.toFloat64()
+	case *BigFloat:
+		return bigFloatOp(a, y, (*big.Float).Mul)
+	case *Decimal:
+		return decimalOp(a, y, mulDecimal, Number.Mul)
 	}
 	panic(fmt.Sprintf("%s.Mul(%s)", a.String(), b.String()))
 }
 
+// MulF returns a * b.  See AddF.
+func (a Float64) MulF(b Float64) Float64 {
+	return a * b
+}
+
 func (a *BigInt) Mul(b Number) Number {
 	switch y := b.(type) {
 	case Int32:
@@ -508,9 +1446,13 @@ func (a *BigInt) Mul(b Number) Number {
 	case Int64:
 		return a.mulBigInt(big.NewInt(int64(y)))
 	case Float64:
-		return a.toFloat64() + y
+		return a.toFloat64() * y
 	case *BigInt:
 		return a.mulBigInt((*big.Int)(y))
+	case *BigFloat:
+		return bigFloatOp(a, y, (*big.Float).Mul)
+	case *Decimal:
+		return decimalOp(a, y, mulDecimal, Number.Mul)
 	}
 	panic(fmt.Sprintf("%s.Mul(%s)", a.String(), b.String()))
 }
@@ -535,14 +1477,51 @@ func (a Float64) RQuo(b Number) Float64 {
 		return a / y
 	case *BigInt:
 		return a / y.toFloat64()
+	case *BigFloat:
+		return rquoBigFloat(a, y)
+	case *Decimal:
+		return rquoDecimal(a, y)
 	}
 	panic(fmt.Sprintf("%s.RQuo(%s)", a.String(), b.String()))
 }
 
+// QuoF returns a / b as RQuo does.  See AddF.
+func (a Float64) QuoF(b Float64) Float64 {
+	return a / b
+}
+
 func (a *BigInt) RQuo(b Number) Float64 {
 	return a.toFloat64().RQuo(b)
 }
 
+// rquoRat returns a / b as a new big.Rat, or nil if a or b is infinite
+// or NaN, or b is zero.
+func rquoRat(a, b Number) *big.Rat {
+	x, y := ToBigRat(a), ToBigRat(b)
+	if x == nil || y == nil || y.Sign() == 0 {
+		return nil
+	}
+	return x.Quo(x, y)
+}
+
+// RQuoRat methods
+
+func (a Int32) RQuoRat(b Number) *big.Rat {
+	return rquoRat(a, b)
+}
+
+func (a Int64) RQuoRat(b Number) *big.Rat {
+	return rquoRat(a, b)
+}
+
+func (a Float64) RQuoRat(b Number) *big.Rat {
+	return rquoRat(a, b)
+}
+
+func (a *BigInt) RQuoRat(b Number) *big.Rat {
+	return rquoRat(a, b)
+}
+
 // QuoRem methods
 
 func (a Int32) QuoRem(b Number) (Number, Number) {
@@ -556,6 +1535,10 @@ func (a Int32) QuoRem(b Number) (Number, Number) {
 	case *BigInt:
 		x := big.NewInt(int64(a))
 		return (*BigInt)(x).quoRemBigInt((*big.Int)(y))
+	case *BigFloat:
+		return quoRemBigFloat(a, y)
+	case *Decimal:
+		return quoRemDecimal(a, y)
 	}
 	panic(fmt.Sprintf("%s.RQuoRem(%s)", a.String(), b.String()))
 }
@@ -571,6 +1554,10 @@ func (a Int64) QuoRem(b Number) (Number, Number) {
 	case *BigInt:
 		x := big.NewInt(int64(a))
 		return (*BigInt)(x).quoRemBigInt((*big.Int)(y))
+	case *BigFloat:
+		return quoRemBigFloat(a, y)
+	case *Decimal:
+		return quoRemDecimal(a, y)
 	}
 	panic(fmt.Sprintf("%s.RQuoRem(%s)", a.String(), b.String()))
 }
@@ -585,6 +1572,10 @@ func (a Float64) QuoRem(b Number) (Number, Number) {
 		return a.quoRemFloat64(y)
 	case *BigInt:
 		return a.quoRemFloat64(y.toFloat64())
+	case *BigFloat:
+		return quoRemBigFloat(a, y)
+	case *Decimal:
+		return quoRemDecimal(a, y)
 	}
 	panic(fmt.Sprintf("%s.RQuoRem(%s)", a.String(), b.String()))
 }
@@ -599,6 +1590,268 @@ func (a *BigInt) QuoRem(b Number) (Number, Number) {
 		return a.toFloat64().quoRemFloat64(y)
 	case *BigInt:
 		return a.quoRemBigInt((*big.Int)(y))
+	case *BigFloat:
+		return quoRemBigFloat(a, y)
+	case *Decimal:
+		return quoRemDecimal(a, y)
 	}
 	panic(fmt.Sprintf("%s.RQuoRem(%s)", a.String(), b.String()))
 }
+
+// IsInteger methods
+
+func (a Int32) IsInteger() bool {
+	return true
+}
+
+func (a Int64) IsInteger() bool {
+	return true
+}
+
+func (a Float64) IsInteger() bool {
+	f := float64(a)
+	return f == math.Trunc(f) && !math.IsInf(f, 0) && !math.IsNaN(f)
+}
+
+func (a *BigInt) IsInteger() bool {
+	return true
+}
+
+// IsOne methods
+
+func (a Int32) IsOne() bool {
+	return a == 1
+}
+
+func (a Int64) IsOne() bool {
+	return a == 1
+}
+
+func (a Float64) IsOne() bool {
+	return a == 1
+}
+
+func (a *BigInt) IsOne() bool {
+	return (*big.Int)(a).Cmp(bigOne) == 0
+}
+
+// IsInf methods
+
+func (a Int32) IsInf(sign int) bool {
+	return false
+}
+
+func (a Int64) IsInf(sign int) bool {
+	return false
+}
+
+func (a Float64) IsInf(sign int) bool {
+	return math.IsInf(float64(a), sign)
+}
+
+func (a *BigInt) IsInf(sign int) bool {
+	return false
+}
+
+// IsNaN methods
+
+func (a Int32) IsNaN() bool {
+	return false
+}
+
+func (a Int64) IsNaN() bool {
+	return false
+}
+
+func (a Float64) IsNaN() bool {
+	return math.IsNaN(float64(a))
+}
+
+func (a *BigInt) IsNaN() bool {
+	return false
+}
+
+// IsNegative methods
+
+func (a Int32) IsNegative() bool {
+	return a < 0
+}
+
+func (a Int64) IsNegative() bool {
+	return a < 0
+}
+
+func (a Float64) IsNegative() bool {
+	return a < 0
+}
+
+func (a *BigInt) IsNegative() bool {
+	return (*big.Int)(a).Sign() < 0
+}
+
+// IsPositive methods
+
+func (a Int32) IsPositive() bool {
+	return a > 0
+}
+
+func (a Int64) IsPositive() bool {
+	return a > 0
+}
+
+func (a Float64) IsPositive() bool {
+	return a > 0
+}
+
+func (a *BigInt) IsPositive() bool {
+	return (*big.Int)(a).Sign() > 0
+}
+
+// Signbit methods
+
+func (a Int32) Signbit() bool {
+	return a < 0
+}
+
+func (a Int64) Signbit() bool {
+	return a < 0
+}
+
+func (a Float64) Signbit() bool {
+	return math.Signbit(float64(a))
+}
+
+func (a *BigInt) Signbit() bool {
+	return (*big.Int)(a).Sign() < 0
+}
+
+// Reduce methods
+
+func (a Int32) Reduce() Number {
+	return a
+}
+
+func (a Int64) Reduce() Number {
+	return a.reduce()
+}
+
+func (a Float64) Reduce() Number {
+	return a
+}
+
+func (a *BigInt) Reduce() Number {
+	return a.reduce()
+}
+
+// ReduceIntegral returns a as an Int32, Int64 or BigInt if a is an
+// integer (see IsInteger).  Otherwise it returns a as is.
+func (a Float64) ReduceIntegral() Number {
+	if a.IsInteger() {
+		return fromIntegral(float64(a))
+	}
+	return a
+}
+
+// Normalize returns a with -0.0 mapped to +0.0.  It returns any other
+// value, including infinities and NaN, as is.  To reduce an integral
+// value to an integer type as well, call a.Normalize().ReduceIntegral().
+func (a Float64) Normalize() Float64 {
+	if a == 0 {
+		return 0
+	}
+	return a
+}
+
+// Bits returns the IEEE 754 binary representation of a, as
+// math.Float64bits does.  Unlike String, it distinguishes every float64
+// value including -0.0 and NaN payloads.
+func (a Float64) Bits() uint64 {
+	return math.Float64bits(float64(a))
+}
+
+// Float64FromBits returns the Float64 of the IEEE 754 binary
+// representation b, as math.Float64frombits does.
+func Float64FromBits(b uint64) Float64 {
+	return Float64(math.Float64frombits(b))
+}
+
+// QuoExact methods
+
+func (a Int32) QuoExact(b Number) (Number, bool) {
+	return Int64(a).QuoExact(b)
+}
+
+func (a Int64) QuoExact(b Number) (Number, bool) {
+	switch y := b.(type) {
+	case Int32:
+		return (*BigInt)(big.NewInt(int64(a))).DivExact((*BigInt)(big.NewInt(int64(y))))
+	case Int64:
+		return (*BigInt)(big.NewInt(int64(a))).DivExact((*BigInt)(big.NewInt(int64(y))))
+	case Float64:
+		return Float64(a).QuoExact(y)
+	case *BigInt:
+		return (*BigInt)(big.NewInt(int64(a))).DivExact(y)
+	case *BigFloat:
+		return quoExactBigFloat(a, y)
+	case *Decimal:
+		return quoExactDecimal(a, y)
+	}
+	panic(fmt.Sprintf("%s.QuoExact(%s)", a.String(), b.String()))
+}
+
+func (a Float64) QuoExact(b Number) (Number, bool) {
+	var q Number
+	var r Float64
+	switch y := b.(type) {
+	case Int32:
+		q, r = a.quoRemFloat64(Float64(y))
+	case Int64:
+		q, r = a.quoRemFloat64(Float64(y))
+	case Float64:
+		q, r = a.quoRemFloat64(y)
+	case *BigInt:
+		q, r = a.quoRemFloat64(y.toFloat64())
+	case *BigFloat:
+		return quoExactBigFloat(a, y)
+	case *Decimal:
+		return quoExactDecimal(a, y)
+	default:
+		panic(fmt.Sprintf("%s.QuoExact(%s)", a.String(), b.String()))
+	}
+	if r != 0 {
+		return nil, false
+	}
+	return q, true
+}
+
+func (a *BigInt) QuoExact(b Number) (Number, bool) {
+	switch y := b.(type) {
+	case Int32:
+		return a.DivExact((*BigInt)(big.NewInt(int64(y))))
+	case Int64:
+		return a.DivExact((*BigInt)(big.NewInt(int64(y))))
+	case Float64:
+		return a.toFloat64().QuoExact(y)
+	case *BigInt:
+		return a.DivExact(y)
+	case *BigFloat:
+		return quoExactBigFloat(a, y)
+	case *Decimal:
+		return quoExactDecimal(a, y)
+	}
+	panic(fmt.Sprintf("%s.QuoExact(%s)", a.String(), b.String()))
+}
+
+// DivExact returns a / b and true if b divides a exactly.
+// Otherwise it returns nil and false.
+// It allocates less than QuoRem since it keeps the remainder in a reused
+// scratch buffer, and it does not compute the quotient at all when the
+// divisibility is rejected by the trailing zero bits or the magnitudes
+// of a and b.
+func (a *BigInt) DivExact(b *BigInt) (Number, bool) {
+	q, ok := divExact((*big.Int)(a), (*big.Int)(b))
+	if !ok {
+		return nil, false
+	}
+	return (*BigInt)(q).reduce(), true
+}
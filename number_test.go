@@ -3,8 +3,13 @@
 package goarith
 
 import (
+	"errors"
 	"fmt"
+	"math"
 	"math/big"
+	"math/rand"
+	"strings"
+	"testing"
 )
 
 func ExampleBigInt_fibonacci() {
@@ -50,9 +55,13 @@ func ExampleFloat64_String() {
 	var a Float64 = 1.234
 	fmt.Println(a.String())
 	fmt.Println(Float64(5.000).String())
+	fmt.Println(Float64(math.Inf(1)), Float64(math.Inf(-1)), Float64(math.NaN()))
+	fmt.Println(NewBigFloat(Float64(math.Inf(-1)), 0))
 	// Output:
 	// 1.234
 	// 5.0
+	// +Inf -Inf NaN
+	// -Inf
 }
 
 func ExampleAsNumber() {
@@ -71,6 +80,151 @@ func ExampleAsNumber() {
 	// goarith.Int64 -2147483649
 }
 
+func ExampleAsNumber_unsigned() {
+	for _, x := range []interface{}{uint(7), uint32(math.MaxUint32),
+		uint64(math.MaxInt64), uint64(math.MaxInt64 + 1),
+		uint64(math.MaxUint64)} {
+		a := AsNumber(x)
+		fmt.Printf("%T %s\n", a, a.String())
+	}
+	// Output:
+	// goarith.Int32 7
+	// goarith.Int64 4294967295
+	// goarith.Int64 9223372036854775807
+	// *goarith.BigInt 9223372036854775808
+	// *goarith.BigInt 18446744073709551615
+}
+
+func ExampleAsNumbers() {
+	a, err := AsNumbers(1, int64(1)<<40, 2.5, float32(0.5), big.NewInt(7))
+	for _, x := range a {
+		fmt.Printf("%T %s\n", x, x.String())
+	}
+	fmt.Println(err)
+	a, err = AsNumbers(1, "two", 3)
+	fmt.Println(a == nil)
+	fmt.Println(err)
+	// Output:
+	// goarith.Int32 1
+	// goarith.Int64 1099511627776
+	// goarith.Float64 2.5
+	// goarith.Float64 0.5
+	// goarith.Int32 7
+	// <nil>
+	// true
+	// goarith: unsupported value two (string) at index 1
+}
+
+func ExampleAsNumberSlice() {
+	x, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	for _, xs := range []interface{}{
+		[]int{1, 1 << 40},
+		[]int32{-1, 2},
+		[]int64{math.MaxInt64},
+		[]uint{1, 1 << 40},
+		[]uint32{math.MaxUint32},
+		[]uint64{math.MaxUint64},
+		[]float32{0.5},
+		[]float64{1.5, math.Inf(-1)},
+		[]*big.Int{big.NewInt(7), x},
+		[]Number{Int32(1), Float64(2)},
+		[]interface{}{1, int32(2), 3.5, x},
+		[]interface{}{1, "0.10"},
+		[]interface{}{1, "two"},
+		[]string{"x"},
+		42,
+	} {
+		ns, err := AsNumberSlice(xs)
+		fmt.Println(ns, err)
+	}
+	// Output:
+	// [1 1099511627776] <nil>
+	// [-1 2] <nil>
+	// [9223372036854775807] <nil>
+	// [1 1099511627776] <nil>
+	// [4294967295] <nil>
+	// [18446744073709551615] <nil>
+	// [0.5] <nil>
+	// [1.5 -Inf] <nil>
+	// [7 123456789012345678901234567890] <nil>
+	// [1 2.0] <nil>
+	// [1 2 3.5 123456789012345678901234567890] <nil>
+	// [1 0.10] <nil>
+	// [] goarith: unsupported value two (string) at index 1
+	// [] goarith: unsupported value x (string) at index 0
+	// [] goarith: not a slice: int
+}
+
+func TestAsNumberSliceTypes(t *testing.T) {
+	ns, err := AsNumberSlice([]int64{1, 1 << 40, -1 << 62})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, want := range []string{"goarith.Int32", "goarith.Int64", "goarith.Int64"} {
+		if got := fmt.Sprintf("%T", ns[i]); got != want {
+			t.Errorf("ns[%d] is %s, want %s", i, got, want)
+		}
+	}
+}
+
+func ExampleKind() {
+	for _, n := range []Number{Int32(1), Int64(1 << 40), Float64(1),
+		Int64(1).Lsh(100), NewBigFloat(Int32(1), 0)} {
+		fmt.Println(Kind(n))
+	}
+	// Output:
+	// int32
+	// int64
+	// float64
+	// bigint
+	// bigfloat
+}
+
+func ExampleZero() {
+	for _, n := range []Number{Int32(7), Int64(1 << 40), Float64(2.5),
+		Int64(1).Lsh(100), NewBigFloat(Int32(3), 100)} {
+		z, o := Zero(n), One(n)
+		fmt.Printf("%T %s, %T %s\n", z, z, o, o)
+	}
+	// Output:
+	// goarith.Int32 0, goarith.Int32 1
+	// goarith.Int64 0, goarith.Int64 1
+	// goarith.Float64 0.0, goarith.Float64 1.0
+	// *goarith.BigInt 0, *goarith.BigInt 1
+	// *goarith.BigFloat 0.0, *goarith.BigFloat 1.0
+}
+
+func TestZeroOnePrecision(t *testing.T) {
+	a := NewBigFloat(Int32(3), 100)
+	if p := (*big.Float)(One(a).(*BigFloat)).Prec(); p != 100 {
+		t.Errorf("One(%s) has precision %d", a, p)
+	}
+	x := Int64(1).Lsh(100).(*BigInt)
+	if z := Zero(x).(*BigInt); z == x || x.Cmp(Int64(1).Lsh(100)) != 0 {
+		t.Errorf("Zero(%s) = %s", x, z)
+	}
+}
+
+func ExampleNewBigInt() {
+	for _, s := range []string{"5", "-2147483649", "+123456789012345678901234567890",
+		"1e3", ""} {
+		n, err := NewBigInt(s)
+		fmt.Printf("%T %v %v\n", n, n, err)
+	}
+	n := NewBigIntFromInt64(-1 << 40)
+	fmt.Printf("%T %v\n", n, n)
+	n = NewBigIntFromInt64(7)
+	fmt.Printf("%T %v\n", n, n)
+	// Output:
+	// goarith.Int32 5 <nil>
+	// goarith.Int64 -2147483649 <nil>
+	// *goarith.BigInt 123456789012345678901234567890 <nil>
+	// <nil> <nil> goarith.NewBigInt: parsing "1e3": invalid syntax
+	// <nil> <nil> goarith.NewBigInt: parsing "": invalid syntax
+	// goarith.Int64 -1099511627776
+	// goarith.Int32 7
+}
+
 func ExampleFloat64_Int() {
 	a := Float64(1.234)
 	i, b := a.Int()
@@ -92,6 +246,144 @@ func ExampleBigInt_Int() {
 	// true false
 }
 
+func ExampleBigInt_IntErr() {
+	x, _ := new(big.Int).SetString("-123456789012345678901234567890", 0)
+	i, err := (*BigInt)(x).IntErr()
+	fmt.Println(i == MinInt, err, errors.Is(err, ErrRange))
+	i, err = (*BigInt)(x.Lsh(x, 1000)).IntErr()
+	fmt.Println(i == MinInt, err)
+	i, err = (*BigInt)(x.Neg(x)).IntErr()
+	fmt.Println(i == MaxInt, err)
+	i, err = (*BigInt)(big.NewInt(-42)).IntErr()
+	fmt.Println(i, err)
+	i, err = Int64(1 << 40).IntErr()
+	fmt.Println(i, err)
+	// Output:
+	// true goarith: value out of range true
+	// true goarith: value out of range
+	// true goarith: value out of range
+	// -42 <nil>
+	// 1099511627776 <nil>
+}
+
+func ExampleFloat64_Int64() {
+	for _, a := range []Float64{1e15, 1.5, -1e19, 1e19} {
+		i, b := a.Int64()
+		fmt.Printf("%d %t\n", i, b)
+	}
+	// Output:
+	// 1000000000000000 true
+	// 1 false
+	// -9223372036854775808 false
+	// 9223372036854775807 false
+}
+
+func ExampleBigInt_Int64() {
+	x, _ := new(big.Int).SetString("9223372036854775807", 10)
+	a := (*BigInt)(x)
+	i, b := a.Int64()
+	fmt.Printf("%d %t\n", i, b)
+	x.Add(x, big.NewInt(1))
+	i, b = a.Int64()
+	fmt.Printf("%d %t\n", i, b)
+	x.Neg(x)
+	i, b = a.Int64()
+	fmt.Printf("%d %t\n", i, b)
+	x.Sub(x, big.NewInt(1))
+	i, b = a.Int64()
+	fmt.Printf("%d %t\n", i, b)
+	i, b = Int64(1 << 40).Int64()
+	fmt.Printf("%d %t\n", i, b)
+	// Output:
+	// 9223372036854775807 true
+	// 9223372036854775807 false
+	// -9223372036854775808 true
+	// -9223372036854775808 false
+	// 1099511627776 true
+}
+
+func ExampleInt64_Uint64() {
+	u, b := Int64(-1).Uint64()
+	fmt.Printf("%d %t\n", u, b)
+	u, b = Int64(math.MaxInt64).Uint64()
+	fmt.Printf("%d %t\n", u, b)
+	// Output:
+	// 0 false
+	// 9223372036854775807 true
+}
+
+func ExampleBigInt_Uint64() {
+	x := new(big.Int).SetUint64(math.MaxUint64)
+	a := (*BigInt)(x)
+	u, b := a.Uint64()
+	fmt.Printf("%d %t\n", u, b)
+	x.Add(x, big.NewInt(1))
+	u, b = a.Uint64()
+	fmt.Printf("%d %t\n", u, b)
+	x.Neg(x)
+	u, b = a.Uint64()
+	fmt.Printf("%d %t\n", u, b)
+	// Output:
+	// 18446744073709551615 true
+	// 18446744073709551615 false
+	// 0 false
+}
+
+func ExampleFloat64_Uint64() {
+	for _, a := range []Float64{1e19, 2.5, -1, 1e20} {
+		u, b := a.Uint64()
+		fmt.Printf("%d %t\n", u, b)
+	}
+	// Output:
+	// 10000000000000000000 true
+	// 2 false
+	// 0 false
+	// 18446744073709551615 false
+}
+
+func ExampleInt64_Int32() {
+	for _, a := range []Number{Int64(1 << 40), Int64(-1 << 40), Int64(-5),
+		(*BigInt)(big.NewInt(math.MaxInt32)), Int64(1).Lsh(80),
+		Float64(2.5), Float64(-2147483648.5), Float64(-3e9), Float64(math.NaN())} {
+		i, ok := a.Int32()
+		fmt.Println(i, ok)
+	}
+	// Output:
+	// 2147483647 false
+	// -2147483648 false
+	// -5 true
+	// 2147483647 true
+	// 2147483647 false
+	// 2 true
+	// -2147483648 true
+	// -2147483648 false
+	// 0 false
+}
+
+func TestInt32Truncation(t *testing.T) {
+	d, _ := ParseDecimal("-7.9")
+	large, _ := ParseDecimal("2147483647.5")
+	for _, c := range []struct {
+		a    Number
+		want int32
+		ok   bool
+	}{
+		{Float64(-0.5), 0, true},
+		{Float64(2147483647.9), math.MaxInt32, true},
+		{Float64(2147483648), math.MaxInt32, false},
+		{Float64(math.Inf(-1)), math.MinInt32, false},
+		{NewBigFloat(Float64(1.75), 0), 1, true},
+		{NewBigFloat(Float64(-1e10), 0), math.MinInt32, false},
+		{d, -7, true},
+		{large, math.MaxInt32, true},
+		{large.Mul(Int32(2)), math.MaxInt32, false},
+	} {
+		if i, ok := c.a.Int32(); i != c.want || ok != c.ok {
+			t.Errorf("%s.Int32() = %d, %t, want %d, %t", c.a, i, ok, c.want, c.ok)
+		}
+	}
+}
+
 func ExampleInt64_QuoRem() {
 	q, r := Int64(13).QuoRem(Int64(4))
 	fmt.Printf("%T %s, %T %s\n", q, q.String(), r, r.String())
@@ -136,3 +428,803 @@ func ExampleBigInt_QuoRem() {
 	// goarith.Int32 -3, goarith.Int32 -1
 	// goarith.Int32 -3, goarith.Int32 1
 }
+
+func ExampleFloat64_IsInteger() {
+	fmt.Println(Float64(2.0).IsInteger())
+	fmt.Println(Float64(2.5).IsInteger())
+	fmt.Println(Float64(math.NaN()).IsInteger())
+	fmt.Println(Float64(math.Inf(1)).IsInteger())
+	fmt.Println(Float64(math.Inf(-1)).IsInteger())
+	fmt.Println(Int32(2).IsInteger())
+	// Output:
+	// true
+	// false
+	// false
+	// false
+	// false
+	// true
+}
+
+func ExampleFloat64_IsInf() {
+	for _, a := range []Float64{Float64(math.Inf(1)), Float64(math.Inf(-1)),
+		Float64(math.NaN()), 1.5} {
+		fmt.Println(a, a.IsInf(1), a.IsInf(-1), a.IsInf(0), a.IsNaN())
+	}
+	// Output:
+	// +Inf true false true false
+	// -Inf false true true false
+	// NaN false false false true
+	// 1.5 false false false false
+}
+
+func TestIsNegativeIsPositive(t *testing.T) {
+	x, _ := new(big.Int).SetString("1"+strings.Repeat("0", 30), 10)
+	negZero := Float64(math.Copysign(0, -1))
+	for _, c := range []struct {
+		a        Number
+		neg, pos bool
+	}{
+		{Int32(0), false, false},
+		{Int32(-1), true, false},
+		{Int32(1), false, true},
+		{Int64(math.MinInt64), true, false},
+		{Int64(math.MaxInt64), false, true},
+		{(*BigInt)(new(big.Int)), false, false},
+		{(*BigInt)(x), false, true},
+		{(*BigInt)(new(big.Int).Neg(x)), true, false},
+		{Float64(0), false, false},
+		{negZero, false, false},
+		{Float64(math.NaN()), false, false},
+		{Float64(-0.5), true, false},
+		{Float64(math.Inf(1)), false, true},
+		{NewBigFloat(negZero, 0), false, false},
+		{NewBigFloat(Float64(-0.5), 0), true, false},
+	} {
+		if c.a.IsNegative() != c.neg || c.a.IsPositive() != c.pos {
+			t.Errorf("%s: IsNegative() = %t, IsPositive() = %t",
+				c.a, c.a.IsNegative(), c.a.IsPositive())
+		}
+	}
+}
+
+func ExampleFloat64_Signbit() {
+	negNaN := Float64(math.Copysign(math.NaN(), -1))
+	for _, a := range []Number{Float64(math.Copysign(0, -1)), Float64(0),
+		negNaN, Float64(math.NaN()), Float64(-1.5), Int32(-1), Int64(0),
+		NewBigFloat(Float64(math.Copysign(0, -1)), 0)} {
+		fmt.Printf("%s %t\n", a, a.Signbit())
+	}
+	// Output:
+	// -0.0 true
+	// 0.0 false
+	// NaN true
+	// NaN false
+	// -1.5 true
+	// -1 true
+	// 0 false
+	// -0.0 true
+}
+
+func ExampleFloat64_IsOne() {
+	for _, a := range []Number{Int32(1), Int64(1), Float64(1.0),
+		(*BigInt)(big.NewInt(1)), NewBigFloat(Int32(1), 100),
+		(*BigInt)(big.NewInt(-1)), Float64(1 + 1e-15), Int32(0)} {
+		fmt.Printf("%s %t\n", a, a.IsOne())
+	}
+	// Output:
+	// 1 true
+	// 1 true
+	// 1.0 true
+	// 1 true
+	// 1.0 true
+	// -1 false
+	// 1.000000000000001 false
+	// 0 false
+}
+
+func TestIsInfIsNaN(t *testing.T) {
+	x, _ := new(big.Int).SetString("1"+strings.Repeat("0", 400), 10)
+	for _, a := range []Number{Int32(math.MaxInt32), Int64(math.MinInt64),
+		(*BigInt)(x), (*BigInt)(new(big.Int).Neg(x)), Float64(math.MaxFloat64),
+		NewBigFloat(Float64(1), 0)} {
+		for sign := -1; sign <= 1; sign++ {
+			if a.IsInf(sign) {
+				t.Errorf("%s.IsInf(%d) = true", a, sign)
+			}
+		}
+		if a.IsNaN() {
+			t.Errorf("%s.IsNaN() = true", a)
+		}
+	}
+	inf := NewBigFloat(Float64(math.Inf(-1)), 0)
+	if !inf.IsInf(-1) || inf.IsInf(1) || !inf.IsInf(0) {
+		t.Errorf("%s.IsInf", inf)
+	}
+}
+
+func ExampleBigInt_Reduce() {
+	x := big.NewInt(-12345)
+	a := (*BigInt)(x).Reduce()
+	fmt.Printf("%T %s\n", a, a.String())
+	x = big.NewInt(1 << 40)
+	a = (*BigInt)(x).Reduce()
+	fmt.Printf("%T %s\n", a, a.String())
+	x.Lsh(x, 40)
+	b := (*BigInt)(x)
+	a = b.Reduce()
+	fmt.Printf("%T %s %t\n", a, a.String(), a == b)
+	a = Int64(7).Reduce()
+	fmt.Printf("%T %s\n", a, a.String())
+	a = Int32(7).Reduce()
+	fmt.Printf("%T %s\n", a, a.String())
+	a = Float64(7).Reduce()
+	fmt.Printf("%T %s\n", a, a.String())
+	// Output:
+	// goarith.Int32 -12345
+	// goarith.Int64 1099511627776
+	// *goarith.BigInt 1208925819614629174706176 true
+	// goarith.Int32 7
+	// goarith.Int32 7
+	// goarith.Float64 7.0
+}
+
+func ExampleFloat64_ReduceIntegral() {
+	for _, f := range []Float64{7, 1e10, 1e20, 7.5} {
+		a := f.ReduceIntegral()
+		fmt.Printf("%T %s\n", a, a.String())
+	}
+	// Output:
+	// goarith.Int32 7
+	// goarith.Int64 10000000000
+	// *goarith.BigInt 100000000000000000000
+	// goarith.Float64 7.5
+}
+
+func ExampleBigInt_DivExact() {
+	x, _ := new(big.Int).SetString("121932631137021795223746380111126352690", 10)
+	y, _ := new(big.Int).SetString("12345678901234567890", 10)
+	q, ok := (*BigInt)(x).DivExact((*BigInt)(y))
+	fmt.Printf("%T %s %t\n", q, q.String(), ok)
+	q, ok = (*BigInt)(x).DivExact((*BigInt)(big.NewInt(7)))
+	fmt.Println(q, ok)
+	q, ok = Int32(12).QuoExact(Int64(-4))
+	fmt.Printf("%T %s %t\n", q, q.String(), ok)
+	q, ok = Int32(12).QuoExact(Int64(8))
+	fmt.Println(q, ok)
+	q, ok = Float64(7.5).QuoExact(Float64(2.5))
+	fmt.Printf("%T %s %t\n", q, q.String(), ok)
+	q, ok = Float64(7.5).QuoExact(Int32(2))
+	fmt.Println(q, ok)
+	// Output:
+	// *goarith.BigInt 9876543210987654321 true
+	// <nil> false
+	// goarith.Int32 -3 true
+	// <nil> false
+	// goarith.Int32 3 true
+	// <nil> false
+}
+
+func TestCmpInt64Float64Exact(t *testing.T) {
+	const p53 = 1 << 53
+	x, _ := new(big.Int).SetString("100000000000000000001", 10) // 1e20 + 1
+	for _, c := range []struct {
+		a    Number
+		f    float64
+		want int
+	}{
+		{Int64(p53 + 1), p53, 1},
+		{Int64(p53 + 1), p53 + 2, -1},
+		{Int64(p53), p53, 0},
+		{Int64(-p53 - 1), -p53, -1},
+		{Int64(math.MaxInt64), 1 << 63, -1},
+		{Int64(math.MaxInt64 - 512), 1<<63 - 1024, 1},
+		{Int64(math.MinInt64), -1 << 63, 0},
+		{Int64(math.MinInt64 + 1), -1 << 63, 1},
+		{Int64(3), 3.5, -1},
+		{Int64(-3), -3.5, 1},
+		{Int64(-3), -2.5, -1},
+		{Int64(1), math.Inf(1), -1},
+		{Int64(1), math.Inf(-1), 1},
+		{Int64(1), math.NaN(), 0},
+		{(*BigInt)(x), 1e20, 1},
+		{(*BigInt)(new(big.Int).Neg(x)), -1e20, -1},
+		{(*BigInt)(x), 1.0000000000000002e20, -1},
+		{(*BigInt)(x), math.Inf(1), -1},
+		{(*BigInt)(x), math.Inf(-1), 1},
+		{(*BigInt)(x), math.NaN(), 0},
+		{(*BigInt)(big.NewInt(p53 + 1)), p53, 1},
+	} {
+		if got := c.a.Cmp(Float64(c.f)); got != c.want {
+			t.Errorf("%s.Cmp(%g) = %d, want %d", c.a, c.f, got, c.want)
+		}
+		if got := Float64(c.f).Cmp(c.a); got != -c.want {
+			t.Errorf("%g.Cmp(%s) = %d, want %d", c.f, c.a, got, -c.want)
+		}
+	}
+}
+
+func ExampleInt64_CmpAbs() {
+	fmt.Println(Int64(-5).CmpAbs(Int32(3)))
+	fmt.Println(Int32(-3).CmpAbs(Float64(3)))
+	fmt.Println(Float64(-0.5).CmpAbs(Int32(-1)))
+	// Output:
+	// 1
+	// 0
+	// -1
+}
+
+func TestCmpAbs(t *testing.T) {
+	minInt64 := Int64(math.MinInt64)
+	maxInt64 := Int64(math.MaxInt64)
+	x, _ := new(big.Int).SetString("-9223372036854775808", 10)
+	y := Int32(1).Lsh(64).Inc().(*BigInt) // 2**64 + 1
+	for _, c := range []struct {
+		a, b Number
+		want int
+	}{
+		{minInt64, maxInt64, 1},
+		{maxInt64, minInt64, -1},
+		{minInt64, minInt64, 0},
+		{minInt64, Int32(math.MinInt32), 1},
+		{Int32(math.MinInt32), Int32(math.MaxInt32), 1},
+		{minInt64, (*BigInt)(x), 0},
+		{(*BigInt)(x), maxInt64, 1},
+		{(*BigInt)(x), Float64(-1e30), -1},
+		{Float64(-1e30), (*BigInt)(x), 1},
+		{Float64(math.Inf(-1)), maxInt64, 1},
+		{Float64(math.NaN()), Int32(1), 0},
+		{NewBigFloat(Float64(-2.5), 0), Int32(2), 1},
+		{Int32(-3), NewBigFloat(Float64(2.5), 0), 1},
+		// Near 2**53 and 2**64, integers are compared exactly as by Cmp.
+		{Float64(1 << 53), Int64(1<<53 + 1), -1},
+		{Float64(-(1 << 53)), Int64(-(1<<53 + 1)), -1},
+		{Int64(-(1<<53 + 1)), Float64(1 << 53), 1},
+		{Float64(1 << 64), y, -1},
+		{y, Float64(-(1 << 64)), 1},
+		{(*BigInt)(new(big.Int).Neg((*big.Int)(y))), Float64(1 << 64), 1},
+		{minInt64, Float64(-(1 << 63)), 0},
+		{Float64(1 << 63), minInt64, 0},
+		{Float64(1 << 63), maxInt64, 1},
+		{Float64(math.NaN()), y, 0},
+	} {
+		if got := c.a.CmpAbs(c.b); got != c.want {
+			t.Errorf("%s.CmpAbs(%s) = %d, want %d", c.a, c.b, got, c.want)
+		}
+	}
+}
+
+func ExampleInt32_Inc() {
+	for _, a := range []Number{Int32(math.MaxInt32), Int64(math.MaxInt64),
+		Int64(math.MaxInt32 + 1), Float64(0.5)} {
+		b := a.Inc()
+		fmt.Printf("%T %s\n", b, b)
+	}
+	for _, a := range []Number{Int32(math.MinInt32), Int64(math.MinInt64),
+		Int64(math.MinInt32 + 1), Float64(0.5)} {
+		b := a.Dec()
+		fmt.Printf("%T %s\n", b, b)
+	}
+	// Output:
+	// goarith.Int64 2147483648
+	// *goarith.BigInt 9223372036854775808
+	// goarith.Int64 2147483649
+	// goarith.Float64 1.5
+	// goarith.Int64 -2147483649
+	// *goarith.BigInt -9223372036854775809
+	// goarith.Int32 -2147483648
+	// goarith.Float64 -0.5
+}
+
+func TestIncDec(t *testing.T) {
+	x, _ := new(big.Int).SetString("9223372036854775808", 10)
+	for _, a := range []Number{Int32(0), Int32(math.MaxInt32),
+		Int32(math.MinInt32), Int64(math.MaxInt64), Int64(math.MinInt64),
+		Int64(math.MaxInt32 + 1), Int64(math.MinInt32 - 1), (*BigInt)(x),
+		(*BigInt)(new(big.Int).Neg(x)), Float64(-1.5),
+		NewBigFloat(Float64(2.5), 0)} {
+		if b, c := a.Inc(), a.Add(Int32(1)); fmt.Sprintf("%T %s", b, b) != fmt.Sprintf("%T %s", c, c) {
+			t.Errorf("%s.Inc() = %T %s, want %T %s", a, b, b, c, c)
+		}
+		if b, c := a.Dec(), a.Sub(Int32(1)); fmt.Sprintf("%T %s", b, b) != fmt.Sprintf("%T %s", c, c) {
+			t.Errorf("%s.Dec() = %T %s, want %T %s", a, b, b, c, c)
+		}
+	}
+}
+
+func TestOnPromote(t *testing.T) {
+	var got []string
+	OnPromote = func(from, to string) { got = append(got, from+"->"+to) }
+	defer func() { OnPromote = nil }()
+	for _, c := range []struct {
+		f    func() Number
+		want string
+	}{
+		{func() Number { return Int32(1).Add(Int32(2)) }, ""},
+		{func() Number { return Int64(1 << 40).Add(Int32(2)) }, ""},
+		{func() Number { return Int64(1 << 40).Sub(Int64(1 << 40)) }, ""},
+		{func() Number { return Int32(math.MaxInt32).Add(Int32(1)) }, "Int32->Int64"},
+		{func() Number { return Int32(math.MinInt32).Sub(Int32(1)) }, "Int32->Int64"},
+		{func() Number { return Int32(1 << 20).Mul(Int32(1 << 20)) }, "Int32->Int64"},
+		{func() Number { return Int32(math.MaxInt32).Inc() }, "Int32->Int64"},
+		{func() Number { return Int64(math.MaxInt64).Add(Int32(1)) }, "Int64->BigInt"},
+		{func() Number { return Int32(-1).Sub(Int64(math.MaxInt64)).Dec() }, "Int64->BigInt"},
+		{func() Number { return Int64(1 << 40).Mul(Int64(1 << 40)) }, "Int64->BigInt"},
+		{func() Number { return Int32(0).Sub(Int64(math.MinInt64)) }, "Int64->BigInt"},
+	} {
+		got = nil
+		r := c.f()
+		if s := strings.Join(got, ","); s != c.want {
+			t.Errorf("%s: OnPromote called with %q, want %q", r, s, c.want)
+		}
+	}
+}
+
+func BenchmarkInc(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var n Number = Int32(0)
+		for j := 0; j < 1000; j++ {
+			n = n.Inc()
+		}
+	}
+}
+
+func BenchmarkAddOne(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var n Number = Int32(0)
+		for j := 0; j < 1000; j++ {
+			n = n.Add(Int32(1))
+		}
+	}
+}
+
+func TestBigIntAddInPlace(t *testing.T) {
+	start, _ := new(big.Int).SetString("1"+strings.Repeat("0", 30), 10)
+	a := (*BigInt)(new(big.Int).Set(start))
+	var want Number = (*BigInt)(start)
+	for i := int64(-5000); i < 5000; i++ {
+		var b Number = Int64(i * 1e12)
+		if i%3 == 0 {
+			b = Int32(i)
+		} else if i%7 == 0 {
+			b = (*BigInt)(new(big.Int).Neg(start))
+		}
+		if r := a.AddInPlace(b); r != a {
+			t.Fatalf("AddInPlace returned %p, want %p", r, a)
+		}
+		want = want.Add(b)
+	}
+	if a.Cmp(want) != 0 {
+		t.Errorf("AddInPlace = %s, want %s", a, want)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("AddInPlace(Float64) did not panic")
+		}
+	}()
+	a.AddInPlace(Float64(1))
+}
+
+func BenchmarkBigIntAdd(b *testing.B) {
+	x, _ := new(big.Int).SetString("1"+strings.Repeat("0", 30), 10)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var n Number = (*BigInt)(x)
+		for j := 0; j < 1000; j++ {
+			n = n.Add(Int64(j))
+		}
+	}
+}
+
+func BenchmarkBigIntAddInPlace(b *testing.B) {
+	x, _ := new(big.Int).SetString("1"+strings.Repeat("0", 30), 10)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		n := (*BigInt)(new(big.Int).Set(x))
+		for j := 0; j < 1000; j++ {
+			n.AddInPlace(Int64(j))
+		}
+	}
+}
+
+func ExampleInt64_RQuoRat() {
+	x, _ := new(big.Int).SetString("100000000000000000000000000000", 10)
+	fmt.Println(Int64(1).RQuoRat(Int64(3)))
+	fmt.Println(Int32(6).RQuoRat(Int32(-4)))
+	fmt.Println((*BigInt)(x).RQuoRat(Int64(3)))
+	fmt.Println(Float64(0.75).RQuoRat(Int32(3)))
+	fmt.Println(Int32(1).RQuoRat(Int32(0)) == nil)
+	fmt.Println(Float64(math.Inf(1)).RQuoRat(Int32(2)) == nil)
+	// Output:
+	// 1/3
+	// -3/2
+	// 100000000000000000000000000000/3
+	// 1/4
+	// true
+	// true
+}
+
+func TestRQuoRat(t *testing.T) {
+	r := Int64(1).RQuoRat(Int64(3))
+	if r.Cmp(big.NewRat(1, 3)) != 0 {
+		t.Errorf("1 / 3 = %s", r)
+	}
+	// The exact quotient differs from the rounded one.
+	f, _ := r.Float64()
+	if r.Cmp(new(big.Rat).SetFloat64(f)) == 0 {
+		t.Errorf("1 / 3 = %s is a float64", r)
+	}
+	if r.Mul(r, big.NewRat(3, 1)); r.Cmp(big.NewRat(1, 1)) != 0 {
+		t.Errorf("1 / 3 * 3 = %s", r)
+	}
+}
+
+func ExampleNum() {
+	for _, n := range []Number{Int64(5), Int32(-7), Float64(0.75),
+		Float64(-2.5), NewBigFloat(Float64(0.125), 0)} {
+		fmt.Println(Num(n), Denom(n))
+	}
+	fmt.Println(Num(Float64(math.NaN())) == nil, Denom(Float64(math.Inf(1))) == nil)
+	// Output:
+	// 5 1
+	// -7 1
+	// 3 4
+	// -5 2
+	// 1 8
+	// true true
+}
+
+func ExampleSimplify() {
+	x, _ := new(big.Int).SetString("-123456789012345678901234567890", 10)
+	for _, c := range [][2]Number{
+		{Int32(6), Int32(-4)}, {Int32(0), Int32(5)}, {Int32(-3), Int32(-9)},
+		{Int64(math.MinInt64), Int32(-2)}, {(*BigInt)(x), Int64(30)}, {Int32(7), Int32(1)},
+		{Int32(1), Int32(0)}, {Float64(0.5), Int32(2)}, {Float64(4), Float64(-2)},
+		{NewDecimal(big.NewInt(-600), 2), Int32(4)}, {Int32(1), Float64(math.Inf(1))},
+	} {
+		n, d, err := Simplify(c[0], c[1])
+		fmt.Println(n, d, err)
+	}
+	// Output:
+	// -3 2 <nil>
+	// 0 1 <nil>
+	// 1 3 <nil>
+	// 4611686018427387904 1 <nil>
+	// -4115226300411522630041152263 1 <nil>
+	// 7 1 <nil>
+	// <nil> <nil> goarith: zero denominator in 1/0
+	// <nil> <nil> goarith: non-integral value in 0.5/2
+	// -2 1 <nil>
+	// -3 2 <nil>
+	// <nil> <nil> goarith: non-integral value in 1/+Inf
+}
+
+func ExampleRationalize() {
+	for _, c := range []struct {
+		f   Float64
+		max int64
+	}{
+		{0.5, 10}, {0.333333333, 100}, {-0.75, 3}, {math.Pi, 7}, {math.Pi, 1000},
+		{math.Pi, 100}, {math.E, 1000}, {3, 1}, {0.1, 1 << 62},
+		{0.25, 2}, {0.75, 2},
+	} {
+		num, den := Rationalize(c.f, c.max)
+		fmt.Printf("%d/%d\n", num, den)
+	}
+	// Output:
+	// 1/2
+	// 1/3
+	// -2/3
+	// 22/7
+	// 355/113
+	// 311/99
+	// 1457/536
+	// 3/1
+	// 3602879701896397/36028797018963968
+	// 0/1
+	// 1/1
+}
+
+func TestRationalize(t *testing.T) {
+	// Compare with a brute-force search of the closest fraction.
+	for _, f := range []float64{0.1, 0.7182818, -1.41421356, 2.5e-3, 123.456} {
+		for _, max := range []int64{1, 2, 5, 17, 100, 257} {
+			num, den := Rationalize(Float64(f), max)
+			x := new(big.Rat).SetFloat64(f)
+			got := new(big.Rat).Sub(big.NewRat(num, den), x)
+			got.Abs(got)
+			for q := int64(1); q <= max; q++ {
+				p := int64(math.Round(f * float64(q)))
+				d := new(big.Rat).Sub(big.NewRat(p, q), x)
+				if d.Abs(d).Cmp(got) < 0 {
+					t.Errorf("Rationalize(%g, %d) = %d/%d, but %d/%d is closer",
+						f, max, num, den, p, q)
+				}
+			}
+		}
+	}
+}
+
+func ExampleFloat32() {
+	x, _ := new(big.Int).SetString("1"+strings.Repeat("0", 40), 10)
+	d, _ := ParseDecimal("0.5")
+	for _, n := range []Number{Int32(3), Int64(1 << 30), Int64(1<<30 + 1), Int64(1<<24 + 1),
+		Float64(0.5), Float64(0.1), Float64(1e39), Float64(math.Inf(-1)),
+		(*BigInt)(x), d} {
+		fmt.Println(Float32(n))
+	}
+	// Output:
+	// 3 true
+	// 1.0737418e+09 true
+	// 1.0737418e+09 false
+	// 1.6777216e+07 false
+	// 0.5 true
+	// 0.1 false
+	// +Inf false
+	// -Inf true
+	// +Inf false
+	// 0.5 true
+}
+
+func ExampleToComplex128() {
+	fmt.Println(ToComplex128(Int64(3)), ToComplex128(Float64(2.5)))
+	fmt.Println(ToComplex128(Int64(1<<53 + 1)))
+	fmt.Println(ToComplex128(Float64(math.Inf(-1))))
+	// Output:
+	// (3+0i) (2.5+0i)
+	// (9.007199254740992e+15+0i)
+	// (-Inf+0i)
+}
+
+func ExampleToBigRat() {
+	x, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	for _, n := range []Number{Float64(0.5), Float64(-3), Float64(0.1),
+		Int32(-7), Int64(1 << 40), (*BigInt)(x),
+		NewBigFloat(Float64(0.75), 0)} {
+		fmt.Println(ToBigRat(n))
+	}
+	fmt.Println(ToBigRat(Float64(math.Inf(1))) == nil)
+	fmt.Println(ToBigRat(Float64(math.NaN())) == nil)
+	// Output:
+	// 1/2
+	// -3/1
+	// 3602879701896397/36028797018963968
+	// -7/1
+	// 1099511627776/1
+	// 123456789012345678901234567890/1
+	// 3/4
+	// true
+	// true
+}
+
+func ExampleFloat64_Normalize() {
+	negZero := Float64(math.Copysign(0, -1))
+	fmt.Println(negZero, negZero.Normalize())
+	fmt.Println(math.Signbit(float64(negZero.Normalize())))
+	for _, a := range []Float64{3, 2.5, Float64(math.Inf(-1)), Float64(math.NaN())} {
+		b := a.Normalize().ReduceIntegral()
+		fmt.Printf("%T %s\n", b, b)
+	}
+	// Output:
+	// -0.0 0.0
+	// false
+	// goarith.Int32 3
+	// goarith.Float64 2.5
+	// goarith.Float64 -Inf
+	// goarith.Float64 NaN
+}
+
+func ExampleFloat64_Bits() {
+	fmt.Printf("%#016x\n", Float64(1).Bits())
+	fmt.Printf("%#016x\n", Float64(math.Copysign(0, -1)).Bits())
+	fmt.Println(Float64FromBits(0x4004000000000000))
+	// Output:
+	// 0x3ff0000000000000
+	// 0x8000000000000000
+	// 2.5
+}
+
+func TestFloat64BitsRoundTrip(t *testing.T) {
+	for _, b := range []uint64{
+		0x0000000000000000, // +0.0
+		0x8000000000000000, // -0.0
+		0x7ff0000000000000, // +Inf
+		0xfff0000000000000, // -Inf
+		0x7ff8000000000001, // quiet NaN with a payload
+		0xfff8000000000abc, // negative quiet NaN with a payload
+		0x7ff0000000000001, // signaling NaN
+		0x0000000000000001, // the smallest subnormal
+		0x7fefffffffffffff, // math.MaxFloat64
+	} {
+		if got := Float64FromBits(b).Bits(); got != b {
+			t.Errorf("Float64FromBits(%#x).Bits() = %#x", b, got)
+		}
+	}
+}
+
+func TestBigIntDivExact(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		x := new(big.Int).Rand(rnd, new(big.Int).Lsh(bigOne, 300))
+		y := new(big.Int).Rand(rnd, new(big.Int).Lsh(bigOne, uint(1+rnd.Intn(200))))
+		if y.Sign() == 0 {
+			continue
+		}
+		if i%2 == 0 {
+			x.Mul(x, y)
+		}
+		if i%3 == 0 {
+			x.Neg(x)
+		}
+		a, b := (*BigInt)(x), (*BigInt)(y)
+		q, r := a.QuoRem(b)
+		e, ok := a.DivExact(b)
+		if exact := r.Cmp(Int32(0)) == 0; ok != exact {
+			t.Fatalf("%s.DivExact(%s): %t, want %t", a, b, ok, exact)
+		} else if ok && e.Cmp(q) != 0 {
+			t.Fatalf("%s.DivExact(%s) = %s, want %s", a, b, e, q)
+		}
+	}
+}
+
+func TestFloat64QuoRemQuotientType(t *testing.T) {
+	inf := Float64(math.Inf(1))
+	for _, c := range []struct {
+		a, b Float64
+		want string
+	}{
+		{7, 2, "goarith.Int32"},
+		{-7.5, 0.5, "goarith.Int32"},
+		{1e10, 1, "goarith.Int64"},
+		{-1e18, 3, "goarith.Int64"},
+		{1e19, 1, "*goarith.BigInt"},
+		{1e300, 3, "*goarith.BigInt"},
+		{-1e300, 3, "*goarith.BigInt"},
+		{1, 0, "goarith.Float64"},
+		{inf, 2, "goarith.Float64"},
+		{Float64(math.NaN()), 2, "goarith.Float64"},
+		{1, inf, "goarith.Int32"},
+	} {
+		q, _ := c.a.QuoRem(c.b)
+		if got := fmt.Sprintf("%T", q); got != c.want {
+			t.Errorf("%g.QuoRem(%g): quotient %s %s, want %s",
+				c.a, c.b, got, q, c.want)
+		}
+	}
+}
+
+func TestFloat64QuoRemLarge(t *testing.T) {
+	for _, f := range []float64{1e19, -0x1p70, 1e300 / 3, 1e308,
+		-math.MaxFloat64} {
+		// f is an integer m * 2**e exactly.
+		m, e := math.Frexp(f)
+		want := big.NewInt(int64(m * (1 << 53)))
+		want.Lsh(want, uint(e-53))
+		q, r := Float64(f).QuoRem(Float64(1))
+		if x, ok := q.(*BigInt); !ok || (*big.Int)(x).Cmp(want) != 0 {
+			t.Errorf("%g.QuoRem(1) = %T %s, want %s", f, q, q, want)
+		}
+		if r.Cmp(Int32(0)) != 0 {
+			t.Errorf("%g.QuoRem(1): remainder %s", f, r)
+		}
+	}
+}
+
+func benchmarkBigIntDivision(b *testing.B, f func(x, y *BigInt)) {
+	x := new(big.Int).Exp(big.NewInt(3), big.NewInt(2000), nil)
+	y := new(big.Int).Exp(big.NewInt(3), big.NewInt(700), nil)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		f((*BigInt)(x), (*BigInt)(y))
+	}
+}
+
+func BenchmarkBigIntDivExact(b *testing.B) {
+	benchmarkBigIntDivision(b, func(x, y *BigInt) { x.DivExact(y) })
+}
+
+func BenchmarkBigIntQuoRem(b *testing.B) {
+	benchmarkBigIntDivision(b, func(x, y *BigInt) { x.QuoRem(y) })
+}
+
+func TestBigIntDivExactAllocs(t *testing.T) {
+	x := (*BigInt)(new(big.Int).Exp(big.NewInt(3), big.NewInt(2000), nil))
+	y := (*BigInt)(new(big.Int).Exp(big.NewInt(3), big.NewInt(700), nil))
+	d := testing.AllocsPerRun(100, func() { x.DivExact(y) })
+	q := testing.AllocsPerRun(100, func() { x.QuoRem(y) })
+	if d >= q {
+		t.Errorf("DivExact makes %g allocations, QuoRem %g", d, q)
+	}
+}
+
+func TestSelfOperations(t *testing.T) {
+	x, _ := new(big.Int).SetString("-123456789012345678901234567890", 10)
+	for _, a := range []Number{(*BigInt)(x), NewBigFloat(Float64(-2.5), 100)} {
+		before := a.String()
+		for _, c := range []struct {
+			name      string
+			got, want func() Number
+		}{
+			{"Add", func() Number { return a.Add(a) }, func() Number { return a.Mul(Int32(2)) }},
+			{"Sub", func() Number { return a.Sub(a) }, func() Number { return Int32(0) }},
+			{"Mul", func() Number { return a.Mul(a) }, func() Number { return a.Pow(Int32(2)) }},
+			{"Pow", func() Number { return a.Pow(Int32(3)) }, func() Number { return a.Mul(a).Mul(a) }},
+			{"QuoRem", func() Number { q, _ := a.QuoRem(a); return q }, func() Number { return Int32(1) }},
+			{"Mean", func() Number { return Mean(a, a) }, func() Number { return a }},
+			{"Sum", func() Number { return Sum([]Number{a, a, a}) }, func() Number { return a.Mul(Int32(3)) }},
+		} {
+			if got, want := c.got(), c.want(); got.Cmp(want) != 0 {
+				t.Errorf("%s: %s = %s, want %s", before, c.name, got, want)
+			}
+			if a.String() != before {
+				t.Fatalf("%s modified the receiver %s into %s", c.name, before, a)
+			}
+		}
+		if y, ok := a.(*BigInt); ok {
+			for _, f := range []func() Number{
+				func() Number { return y.And(y) }, func() Number { return y.Or(y) },
+				func() Number { return y.Xor(y).Add(y) }, func() Number { return y.AndNot(y).Add(y) },
+			} {
+				if got := f(); got.Cmp(y) != 0 {
+					t.Errorf("%s: bitwise self-operation = %s", before, got)
+				}
+			}
+			if got := MulMod(y, y, y); got.Cmp(Int32(0)) != 0 {
+				t.Errorf("MulMod(x, x, x) = %s", got)
+			}
+			if a.String() != before {
+				t.Fatalf("bitwise operations modified the receiver %s into %s", before, a)
+			}
+		}
+	}
+}
+
+func TestBigIntMulFloat64(t *testing.T) {
+	x := Int64(1).Lsh(70)
+	if got := x.Mul(Float64(0.5)); got.Cmp(Int64(1).Lsh(69)) != 0 {
+		t.Errorf("%s.Mul(0.5) = %s", x, got)
+	}
+	if got := Float64(0.5).Mul(x); got.Cmp(Int64(1).Lsh(69)) != 0 {
+		t.Errorf("0.5.Mul(%s) = %s", x, got)
+	}
+}
+
+func TestFloat64Direct(t *testing.T) {
+	special := []Float64{0, Float64(math.Copysign(0, -1)), 1, -2.5, 1e308,
+		Float64(math.SmallestNonzeroFloat64), Float64(math.Inf(1)),
+		Float64(math.Inf(-1)), Float64(math.NaN())}
+	for _, a := range special {
+		for _, b := range special {
+			for _, c := range []struct {
+				name      string
+				got, want Number
+			}{
+				{"AddF", a.AddF(b), a.Add(b)},
+				{"SubF", a.SubF(b), a.Sub(b)},
+				{"MulF", a.MulF(b), a.Mul(b)},
+				{"QuoF", a.QuoF(b), a.RQuo(b)},
+			} {
+				if c.got.(Float64).Bits() != c.want.(Float64).Bits() {
+					t.Errorf("%s.%s(%s) = %s, want %s", a, c.name, b, c.got, c.want)
+				}
+			}
+		}
+	}
+}
+
+func BenchmarkFloat64Add(b *testing.B) {
+	var n Number = Float64(0)
+	for i := 0; i < b.N; i++ {
+		n = n.Add(Float64(0.5))
+	}
+	_ = n
+}
+
+func BenchmarkFloat64AddF(b *testing.B) {
+	n := Float64(0)
+	for i := 0; i < b.N; i++ {
+		n = n.AddF(0.5)
+	}
+	_ = n
+}
@@ -0,0 +1,322 @@
+package goarith
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+)
+
+// bigOperand returns the big.Int value of an Int32, Int64 or *BigInt,
+// setting it to s for Int32 and Int64.  The result must not be modified.
+// For the other types, it returns nil.
+func bigOperand(n Number, s *big.Int) *big.Int {
+	switch x := n.(type) {
+	case Int32:
+		return s.SetInt64(int64(x))
+	case Int64:
+		return s.SetInt64(int64(x))
+	case *BigInt:
+		return (*big.Int)(x)
+	}
+	return nil
+}
+
+// mulAddInt returns x * y + z exactly for integers x, y and z.
+// It returns nil if some of them are not integers.
+func mulAddInt(x, y, z Number) Number {
+	s := scratchPool.Get().(*[3]big.Int)
+	defer scratchPool.Put(s)
+	a, b, c := bigOperand(x, &s[0]), bigOperand(y, &s[1]), bigOperand(z, &s[2])
+	if a == nil || b == nil || c == nil {
+		return nil
+	}
+	r := new(big.Int).Mul(a, b)
+	return (*BigInt)(r.Add(r, c)).reduce()
+}
+
+// FMA returns x * y + z.
+// If x, y and z are all integers, it computes the result exactly.
+// If they are all integers or *Decimal, it computes the result exactly
+// as a *Decimal.
+// Otherwise it computes the result as a Float64 by math.FMA with only
+// one rounding.  If any of them is a *BigFloat, it computes the result
+// as a *BigFloat of the maximum precision of the operands, again with
+// only one rounding.
+func FMA(x, y, z Number) Number {
+	if r := mulAddInt(x, y, z); r != nil {
+		return r
+	}
+	if a, ok := toDecimal(x); ok {
+		if b, ok := toDecimal(y); ok {
+			if c, ok := toDecimal(z); ok {
+				return addDecimal(mulDecimal(a, b), c)
+			}
+		}
+	}
+	prec := bigFloatPrec(x, y)
+	if p := bigFloatPrec(z, nil); p > prec {
+		prec = p
+	}
+	if prec != 0 {
+		// The product is exact with the sum of the precisions.
+		p := bigFloatOp(x, y, func(r, s, t *big.Float) *big.Float {
+			return r.SetPrec(s.Prec()+t.Prec()).Mul(s, t)
+		})
+		if _, ok := p.(*BigFloat); !ok {
+			return p // NaN
+		}
+		return bigFloatOp(p, z, func(r, s, t *big.Float) *big.Float {
+			return r.SetPrec(prec).Add(s, t)
+		})
+	}
+	return Float64(math.FMA(float64(asFloat64(x)), float64(asFloat64(y)),
+		float64(asFloat64(z))))
+}
+
+// MulAdd returns a * b + c.  The result is the same as a.Mul(b).Add(c),
+// but for integers it is computed in a single big.Int without any
+// intermediate BigInt.  It is useful in Horner's method, for example.
+// Note that MulAdd rounds a Float64 result twice; see FMA.
+func MulAdd(a, b, c Number) Number {
+	if x, ok := a.(Int32); ok {
+		if y, ok := b.(Int32); ok {
+			return Int64(int64(x) * int64(y)).Add(c)
+		}
+	}
+	if r := mulAddInt(a, b, c); r != nil {
+		return r
+	}
+	return a.Mul(b).Add(c)
+}
+
+// Sum returns the sum of ns by folding them with Add from Int32(0).
+// It returns Int32(0) for an empty slice.
+func Sum(ns []Number) Number {
+	var r Number = Int32(0)
+	for _, n := range ns {
+		r = r.Add(n)
+	}
+	return r
+}
+
+// Map returns a new slice of f(n) for each n in ns, e.g.
+// Map(ns, Number.Inc).  It returns nil for an empty ns.
+func Map(ns []Number, f func(Number) Number) []Number {
+	if len(ns) == 0 {
+		return nil
+	}
+	result := make([]Number, len(ns))
+	for i, n := range ns {
+		result[i] = f(n)
+	}
+	return result
+}
+
+// Pipe applies fs to a in sequence and returns the result, i.e.
+// fs[n-1](...fs[1](fs[0](a))), e.g. Pipe(x, square, Number.Inc) for
+// x*x + 1.  It returns a as is if fs is empty.
+func Pipe(a Number, fs ...func(Number) Number) Number {
+	for _, f := range fs {
+		a = f(a)
+	}
+	return a
+}
+
+// ZipWith returns a new slice of f(a[i], b[i]) for each i, e.g.
+// ZipWith(a, b, Number.Add) for the elementwise sum.  Each element is
+// reduced as f reduces it.  It returns an error if a and b differ in
+// length, and nil for empty a and b.
+func ZipWith(a, b []Number, f func(Number, Number) Number) ([]Number, error) {
+	if len(a) != len(b) {
+		return nil, fmt.Errorf("goarith: lengths differ: %d and %d", len(a), len(b))
+	} else if len(a) == 0 {
+		return nil, nil
+	}
+	result := make([]Number, len(a))
+	for i := range a {
+		result[i] = f(a[i], b[i])
+	}
+	return result, nil
+}
+
+// KahanSum accumulates a sum of Numbers with the Kahan-Babuška-Neumaier
+// compensated summation, which keeps the rounding error of float64
+// additions in a separate term.  It is far more accurate than folding
+// Float64s with Add, e.g. as Sum does.  Integers are summed exactly
+// apart from the other Numbers and converted into float64 only by
+// Value.  The other Numbers are converted into float64 when added.
+// The zero value is an empty sum.
+type KahanSum struct {
+	n    Number  // the exact sum of the integers, or nil
+	s, c float64 // the sum of the others and its compensation
+}
+
+// Add adds x to the sum.
+func (k *KahanSum) Add(x Number) {
+	switch x.(type) {
+	case Int32, Int64, *BigInt:
+		if k.n == nil {
+			k.n = x
+		} else {
+			k.n = k.n.Add(x)
+		}
+	default:
+		k.add(float64(asFloat64(x)))
+	}
+}
+
+// add adds f to the float64 sum, keeping the lost low-order bits in c.
+func (k *KahanSum) add(f float64) {
+	t := k.s + f
+	if math.Abs(k.s) >= math.Abs(f) {
+		k.c += (k.s - t) + f
+	} else {
+		k.c += (f - t) + k.s
+	}
+	k.s = t
+}
+
+// Value returns the sum rounded to a Float64.  It returns 0 for an
+// empty sum.
+func (k *KahanSum) Value() Float64 {
+	v := *k
+	if v.n != nil {
+		// Add the integer sum as the float64 nearest to it and the rest.
+		hi := asFloat64(v.n)
+		v.add(float64(hi))
+		if !hi.IsInf(0) {
+			z, _ := new(big.Float).SetFloat64(float64(hi)).Int(nil)
+			v.add(float64(asFloat64((*BigInt)(z.Sub(toBigInt(v.n), z)))))
+		}
+	}
+	if math.IsInf(v.s, 0) || math.IsNaN(v.s) {
+		return Float64(v.s) // c may be NaN here.
+	}
+	return Float64(v.s + v.c)
+}
+
+// Product returns the product of ns by folding them with Mul from
+// Int32(1).  It returns Int32(1) for an empty slice.
+func Product(ns []Number) Number {
+	var r Number = Int32(1)
+	for _, n := range ns {
+		r = r.Mul(n)
+	}
+	return r
+}
+
+// Mean returns the midpoint of a and b without overflow.
+// If a and b are integers, it returns floor((a + b) / 2) exactly, which
+// lies between a and b and so is never promoted beyond the wider of
+// them.  If a or b is a *BigFloat, the result is a *BigFloat.
+// If a and b are integers or *Decimal, the result is an exact *Decimal.
+// Otherwise the result is a Float64.
+func Mean(a, b Number) Number {
+	if x, ok := fixedInt(a); ok {
+		if y, ok := fixedInt(b); ok {
+			// The carry of the low bits corrects the truncated halves.
+			return Int64(x>>1 + y>>1 + x&y&1).reduce()
+		}
+	}
+	if x := toBigInt(a); x != nil {
+		if y := toBigInt(b); y != nil {
+			x.Add(x, y)
+			return (*BigInt)(x.Rsh(x, 1)).reduce() // Rsh rounds toward -Inf.
+		}
+	}
+	if x, ok := toDecimal(a); ok {
+		if y, ok := toDecimal(b); ok {
+			return mulDecimal(addDecimal(x, y), &Decimal{big.NewInt(5), 1})
+		}
+	}
+	if bigFloatPrec(a, b) != 0 {
+		return a.Add(b).Mul(Float64(0.5))
+	}
+	x, y := float64(asFloat64(a)), float64(asFloat64(b))
+	m := (x + y) / 2
+	if math.IsInf(m, 0) && !math.IsInf(x, 0) && !math.IsInf(y, 0) {
+		m = x/2 + y/2
+	}
+	return Float64(m)
+}
+
+// MulAll returns the product of ns as Product does, but multiplies them
+// pairwise in a balanced tree instead of a left fold.  For many large
+// integers it is much faster than Product, since the operands of each
+// multiplication stay of similar size.  It returns Int32(1) for an
+// empty slice.
+func MulAll(ns []Number) Number {
+	zs := make([]*big.Int, len(ns))
+	for i, n := range ns {
+		if zs[i] = toBigInt(n); zs[i] == nil {
+			return mulTree(append([]Number(nil), ns...))
+		}
+	}
+	if len(zs) == 0 {
+		return Int32(1)
+	}
+	for len(zs) > 1 {
+		m := (len(zs) + 1) / 2
+		for i := 0; i < len(zs)/2; i++ {
+			zs[i] = zs[2*i].Mul(zs[2*i], zs[2*i+1])
+		}
+		if len(zs)%2 == 1 {
+			zs[m-1] = zs[len(zs)-1]
+		}
+		zs = zs[:m]
+	}
+	return (*BigInt)(zs[0]).reduce()
+}
+
+// mulTree multiplies ns pairwise in a balanced tree with Mul,
+// overwriting ns.
+func mulTree(ns []Number) Number {
+	for len(ns) > 1 {
+		m := (len(ns) + 1) / 2
+		for i := 0; i < len(ns)/2; i++ {
+			ns[i] = ns[2*i].Mul(ns[2*i+1])
+		}
+		if len(ns)%2 == 1 {
+			ns[m-1] = ns[len(ns)-1]
+		}
+		ns = ns[:m]
+	}
+	return ns[0]
+}
+
+// Range returns the values start, start + step, start + 2*step, ... up
+// to but not including stop.  If step is negative, the values decrease
+// down to but not including stop.  Each value is computed as
+// start + i*step rather than by repeated addition, so that a fractional
+// step does not accumulate rounding errors.  The values are promoted as
+// Add and Mul do, e.g. from Int32 to Int64.  If a value no longer moves
+// toward stop, e.g. because step is lost in rounding a large Float64,
+// Range stops there.
+// It panics if step is zero or NaN, or start or stop is infinite.
+func Range(start, stop, step Number) []Number {
+	var done func(x, prev Number) bool
+	if start.IsInf(0) || stop.IsInf(0) {
+		// Panic below.
+	} else if step.IsPositive() {
+		done = func(x, prev Number) bool {
+			return x.Cmp(stop) >= 0 || x.IsNaN() || (prev != nil && x.Cmp(prev) <= 0)
+		}
+	} else if step.IsNegative() {
+		done = func(x, prev Number) bool {
+			return x.Cmp(stop) <= 0 || x.IsNaN() || (prev != nil && x.Cmp(prev) >= 0)
+		}
+	}
+	if done == nil {
+		panic(fmt.Sprintf("Range(%s, %s, %s)", start.String(), stop.String(), step.String()))
+	}
+	var result []Number
+	var prev Number
+	for i := Number(Int32(0)); ; i = i.Inc() {
+		x := start.Add(i.Mul(step))
+		if done(x, prev) {
+			return result
+		}
+		prev = x
+		result = append(result, x)
+	}
+}
@@ -0,0 +1,357 @@
+package goarith
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+func ExampleFMA() {
+	x := Float64(1 + math.Ldexp(1, -30))
+	y := Float64(1 - math.Ldexp(1, -30))
+	z := Float64(-1)
+	fmt.Println(x.Mul(y).Add(z).String()) // rounded twice
+	fmt.Println(FMA(x, y, z).String())    // rounded once
+	fmt.Println(FMA(x, y, Int32(-1)).String())
+
+	a := FMA(Int64(1<<62), Int64(1<<62), Int32(1))
+	fmt.Printf("%T %s\n", a, a.String())
+	a = FMA(Int64(1<<62), Int32(4), Int64(math.MinInt64))
+	fmt.Printf("%T %s\n", a, a.String())
+	// Output:
+	// 0.0
+	// -8.673617379884035e-19
+	// -8.673617379884035e-19
+	// *goarith.BigInt 21267647932558653966460912964485513217
+	// *goarith.BigInt 9223372036854775808
+}
+
+// polynomial returns the coefficients of a polynomial of degree 1000.
+func polynomial() []Number {
+	coeffs := make([]Number, 1001)
+	for i := range coeffs {
+		coeffs[i] = Int32(i%7 - 3)
+	}
+	return coeffs
+}
+
+func TestMulAdd(t *testing.T) {
+	coeffs := polynomial()
+	for _, x := range []Number{Int32(3), Int64(-1 << 40), Int32(0), Float64(0.999)} {
+		var acc1, acc2 Number = Int32(0), Int32(0)
+		for _, c := range coeffs {
+			acc1 = acc1.Mul(x).Add(c)
+			acc2 = MulAdd(acc2, x, c)
+		}
+		if acc1.Cmp(acc2) != 0 || fmt.Sprintf("%T", acc1) != fmt.Sprintf("%T", acc2) {
+			t.Errorf("MulAdd at %s: got %T %s, want %T %s", x, acc2, acc2, acc1, acc1)
+		}
+	}
+}
+
+func BenchmarkHornerMulAdd(b *testing.B) {
+	coeffs := polynomial()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var acc Number = Int32(0)
+		for _, c := range coeffs {
+			acc = MulAdd(acc, Int32(3), c)
+		}
+	}
+}
+
+func BenchmarkHornerMulThenAdd(b *testing.B) {
+	coeffs := polynomial()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var acc Number = Int32(0)
+		for _, c := range coeffs {
+			acc = acc.Mul(Int32(3)).Add(c)
+		}
+	}
+}
+
+func ExampleSum() {
+	ns := []Number{Int32(math.MaxInt32), Int32(1), Int64(math.MaxInt64),
+		Int64(-math.MaxInt64), Int32(-1)}
+	for i := 0; i <= len(ns); i++ {
+		s := Sum(ns[:i])
+		fmt.Printf("%T %s\n", s, s)
+	}
+	fmt.Println(Sum([]Number{Int32(1), Float64(0.5)}))
+	// Output:
+	// goarith.Int32 0
+	// goarith.Int32 2147483647
+	// goarith.Int64 2147483648
+	// *goarith.BigInt 9223372039002259455
+	// goarith.Int64 2147483648
+	// goarith.Int32 2147483647
+	// 1.5
+}
+
+func ExampleMap() {
+	ns := []Number{Int32(math.MaxInt32), Float64(0.5), Int64(math.MaxInt64)}
+	fmt.Println(Map(ns, Number.Inc))
+	fmt.Println(Map(ns, func(n Number) Number { return n.Mul(n) }))
+	fmt.Println(Map(nil, Number.Inc) == nil)
+	// Output:
+	// [2147483648 1.5 9223372036854775808]
+	// [4611686014132420609 0.25 85070591730234615847396907784232501249]
+	// true
+}
+
+func ExamplePipe() {
+	square := func(n Number) Number { return n.Mul(n) }
+	half := func(n Number) Number { return n.Mul(Float64(0.5)) }
+	for _, x := range []Number{
+		Pipe(Int32(3), square, Number.Inc),
+		Pipe(Int32(math.MaxInt32), square, Number.Inc),
+		Pipe(Int32(3), square, half, Number.Floor),
+		Pipe(Int32(3)),
+	} {
+		fmt.Printf("%T %s\n", x, x)
+	}
+	// Output:
+	// goarith.Int32 10
+	// goarith.Int64 4611686014132420610
+	// goarith.Float64 4.0
+	// goarith.Int32 3
+}
+
+func ExampleZipWith() {
+	a := []Number{Int32(1), Int64(math.MaxInt64), Float64(0.5), Int32(-3)}
+	b := []Number{Int32(2), Int32(1), Int32(1), Int64(3)}
+	sum, err := ZipWith(a, b, Number.Add)
+	for _, n := range sum {
+		fmt.Printf("%T %s\n", n, n)
+	}
+	fmt.Println(err)
+	_, err = ZipWith(a, b[:3], Number.Mul)
+	fmt.Println(err)
+	// Output:
+	// goarith.Int32 3
+	// *goarith.BigInt 9223372036854775808
+	// goarith.Float64 1.5
+	// goarith.Int32 0
+	// <nil>
+	// goarith: lengths differ: 4 and 3
+}
+
+func ExampleKahanSum() {
+	ns := []Number{Float64(1e16)}
+	for i := 0; i < 10000; i++ {
+		ns = append(ns, Float64(1))
+	}
+	ns = append(ns, Float64(-1e16))
+	var k KahanSum
+	for _, n := range ns {
+		k.Add(n)
+	}
+	fmt.Println(Sum(ns), k.Value())
+	var k2 KahanSum
+	k2.Add(Int64(1<<53 + 1))
+	k2.Add(Float64(0.5))
+	k2.Add(Int64(-1 << 53))
+	fmt.Println(k2.Value(), new(KahanSum).Value())
+	// Output:
+	// 0.0 10000.0
+	// 1.5 0.0
+}
+
+func TestKahanSum(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	var k KahanSum
+	exact := new(big.Rat)
+	var naive Number = Int32(0)
+	for i := 0; i < 100000; i++ {
+		f := r.NormFloat64() * math.Pow10(r.Intn(20)-10)
+		k.Add(Float64(f))
+		naive = naive.Add(Float64(f))
+		exact.Add(exact, new(big.Rat).SetFloat64(f))
+	}
+	want, _ := exact.Float64()
+	if got := k.Value(); float64(got) != want {
+		t.Errorf("KahanSum = %s, want %g (naive %s)", got, want, naive)
+	}
+	if float64(naive.(Float64)) == want {
+		t.Log("naive summation happened to be exact")
+	}
+	// Infinities propagate without NaN from the compensation.
+	k.Add(Float64(math.Inf(1)))
+	k.Add(Float64(1))
+	if got := k.Value(); !got.IsInf(1) {
+		t.Errorf("KahanSum with +Inf = %s", got)
+	}
+}
+
+func ExampleProduct() {
+	ns := []Number{Int32(1 << 20), Int32(1 << 20), Int64(1 << 40)}
+	for i := 0; i <= len(ns); i++ {
+		p := Product(ns[:i])
+		fmt.Printf("%T %s\n", p, p)
+	}
+	fmt.Println(Product([]Number{Int32(3), Float64(0.5)}))
+	// Output:
+	// goarith.Int32 1
+	// goarith.Int32 1048576
+	// goarith.Int64 1099511627776
+	// *goarith.BigInt 1208925819614629174706176
+	// 1.5
+}
+
+func ExampleMean() {
+	fmt.Println(Mean(Int32(3), Int32(8)), Mean(Int32(-3), Int32(-8)),
+		Mean(Int32(-3), Int32(4)), Mean(Float64(1), Int32(2)))
+	// Output:
+	// 5 -6 0 1.5
+}
+
+func TestMean(t *testing.T) {
+	const max64, min64 = math.MaxInt64, math.MinInt64
+	x, _ := new(big.Int).SetString("100000000000000000000", 10)
+	for _, c := range []struct {
+		a, b Number
+		want string
+	}{
+		{Int64(max64), Int64(max64), "goarith.Int64 9223372036854775807"},
+		{Int64(max64), Int64(max64 - 1), "goarith.Int64 9223372036854775806"},
+		{Int64(min64), Int64(min64), "goarith.Int64 -9223372036854775808"},
+		{Int64(min64), Int64(max64), "goarith.Int32 -1"},
+		{Int64(min64 + 1), Int64(max64), "goarith.Int32 0"},
+		{Int32(math.MaxInt32), Int32(math.MaxInt32), "goarith.Int32 2147483647"},
+		{Int32(math.MinInt32), Int64(-1), "goarith.Int32 -1073741825"},
+		{Int32(5), Int32(5), "goarith.Int32 5"},
+		{Int32(-5), Int32(-6), "goarith.Int32 -6"},
+		{(*BigInt)(x), Int32(-1), "*goarith.BigInt 49999999999999999999"},
+		{(*BigInt)(x), (*BigInt)(x), "*goarith.BigInt 100000000000000000000"},
+		{Float64(math.MaxFloat64), Float64(math.MaxFloat64), "goarith.Float64 1.7976931348623157e+308"},
+		{Float64(-math.MaxFloat64), Float64(math.MaxFloat64), "goarith.Float64 0.0"},
+		{NewBigFloat(Int32(1), 0), Int32(2), "*goarith.BigFloat 1.5"},
+	} {
+		m := Mean(c.a, c.b)
+		if got := fmt.Sprintf("%T %s", m, m); got != c.want {
+			t.Errorf("Mean(%s, %s) = %s, want %s", c.a, c.b, got, c.want)
+		}
+		if m2 := Mean(c.b, c.a); m2.Cmp(m) != 0 {
+			t.Errorf("Mean(%s, %s) = %s != %s", c.b, c.a, m2, m)
+		}
+	}
+}
+
+func ExampleMulAll() {
+	fmt.Println(MulAll(nil))
+	fmt.Println(MulAll([]Number{Int32(1 << 20), Int64(1 << 40), Int32(3)}))
+	fmt.Println(MulAll([]Number{Int32(3), Float64(0.5), Int32(4)}))
+	// Output:
+	// 1
+	// 3458764513820540928
+	// 6.0
+}
+
+func TestMulAllFactorial(t *testing.T) {
+	for _, n := range []int64{0, 1, 2, 3, 12, 13, 20, 21, 100, 1001} {
+		ns := make([]Number, n)
+		for i := range ns {
+			ns[i] = Int64(i + 1).Reduce()
+		}
+		want := new(big.Int).MulRange(1, n)
+		if got := MulAll(ns); got.Cmp((*BigInt)(want).reduce()) != 0 {
+			t.Errorf("MulAll(1..%d) = %s, want %s", n, got, want)
+		}
+		if n == 12 {
+			if _, ok := MulAll(ns).(Int32); !ok {
+				t.Errorf("MulAll(1..12) is not reduced")
+			}
+		}
+		for i, m := range ns {
+			if m.Cmp(Int64(i+1)) != 0 {
+				t.Errorf("MulAll modified ns[%d] to %s", i, m)
+			}
+		}
+	}
+}
+
+func largeFactors() []Number {
+	rnd := rand.New(rand.NewSource(1))
+	ns := make([]Number, 1000)
+	for i := range ns {
+		ns[i] = (*BigInt)(new(big.Int).Rand(rnd, new(big.Int).Lsh(bigOne, 1000)))
+	}
+	return ns
+}
+
+func BenchmarkMulAll(b *testing.B) {
+	ns := largeFactors()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		MulAll(ns)
+	}
+}
+
+func BenchmarkProduct(b *testing.B) {
+	ns := largeFactors()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Product(ns)
+	}
+}
+
+func ExampleRange() {
+	fmt.Println(Range(Int32(0), Int32(5), Int32(1)))
+	fmt.Println(Range(Int32(5), Int32(0), Int32(-2)))
+	fmt.Println(Range(Float64(0), Float64(1), Float64(0.1)))
+	fmt.Println(Range(Int32(0), Float64(2), Float64(0.5)))
+	fmt.Println(Range(Int32(0), Int32(0), Int32(1)))
+	// Output:
+	// [0 1 2 3 4]
+	// [5 3 1]
+	// [0.0 0.1 0.2 0.30000000000000004 0.4 0.5 0.6000000000000001 0.7000000000000001 0.8 0.9]
+	// [0.0 0.5 1.0 1.5]
+	// []
+}
+
+func TestRangePromotion(t *testing.T) {
+	start := Int32(math.MaxInt32 - 1)
+	r := Range(start, Int64(math.MaxInt32+3), Int32(1))
+	want := []string{"goarith.Int32 2147483646", "goarith.Int32 2147483647",
+		"goarith.Int64 2147483648", "goarith.Int64 2147483649"}
+	if len(r) != len(want) {
+		t.Fatalf("Range = %v", r)
+	}
+	for i, x := range r {
+		if got := fmt.Sprintf("%T %s", x, x); got != want[i] {
+			t.Errorf("r[%d] = %s, want %s", i, got, want[i])
+		}
+	}
+	r = Range(Int64(math.MinInt64+2), Int64(math.MinInt64), Int32(-1))
+	if len(r) != 2 || r[1].Cmp(Int64(math.MinInt64+1)) != 0 {
+		t.Errorf("Range = %v", r)
+	}
+	r = Range(Int64(math.MaxInt64), Int64(1).Lsh(63).Add(Int32(2)), Int32(1))
+	if len(r) != 3 || r[2].String() != "9223372036854775809" {
+		t.Errorf("Range = %v", r)
+	}
+	for _, c := range [][3]Number{
+		{Int32(0), Int32(10), Int32(0)},
+		{Int32(0), Int32(10), Float64(math.NaN())},
+		{Int32(0), Float64(math.Inf(1)), Int32(1)},
+		{Float64(math.Inf(-1)), Int32(0), Int32(1)},
+	} {
+		func() {
+			want := fmt.Sprintf("Range(%s, %s, %s)", c[0], c[1], c[2])
+			defer func() {
+				if e := recover(); e != want {
+					t.Errorf("Range(%s, %s, %s) panicked with %v", c[0], c[1], c[2], e)
+				}
+			}()
+			Range(c[0], c[1], c[2])
+		}()
+	}
+	// The step 1 is lost in rounding 1e300 + i.
+	r = Range(Float64(1e300), Float64(2e300), Int32(1))
+	if len(r) != 1 || r[0] != Float64(1e300) {
+		t.Errorf("Range(1e300, 2e300, 1) = %v", r)
+	}
+}
@@ -0,0 +1,221 @@
+package goarith
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"sort"
+)
+
+// nanSign returns -1 for a NaN with the sign bit, 1 for the other NaN
+// and 0 for non-NaN.
+func nanSign(n Number) int {
+	if x, ok := n.(Float64); ok && math.IsNaN(float64(x)) {
+		if math.Signbit(float64(x)) {
+			return -1
+		}
+		return 1
+	}
+	return 0
+}
+
+// isNegativeZero returns whether n is -0.0.
+func isNegativeZero(n Number) bool {
+	return n.Signbit() && !n.IsNegative() && !n.IsNaN()
+}
+
+// cmpTotal compares a and b in the total order described at CmpTotal.
+func cmpTotal(a, b Number) int {
+	s, t := nanSign(a), nanSign(b)
+	if s != 0 || t != 0 {
+		if s < t {
+			return -1
+		} else if s > t {
+			return 1
+		}
+		return 0
+	}
+	if c := a.Cmp(b); c != 0 {
+		return c
+	}
+	s, t = 0, 0
+	if isNegativeZero(a) {
+		s = -1
+	}
+	if isNegativeZero(b) {
+		t = -1
+	}
+	if s < t {
+		return -1
+	} else if s > t {
+		return 1
+	}
+	return 0
+}
+
+// CmpTotal methods
+
+func (a Int32) CmpTotal(b Number) int {
+	return cmpTotal(a, b)
+}
+
+func (a Int64) CmpTotal(b Number) int {
+	return cmpTotal(a, b)
+}
+
+func (a Float64) CmpTotal(b Number) int {
+	return cmpTotal(a, b)
+}
+
+func (a *BigInt) CmpTotal(b Number) int {
+	return cmpTotal(a, b)
+}
+
+// ErrNaN is returned by CmpErr when an operand is NaN.  Since an
+// integer is never NaN, CmpErr of an integer returns it only for a NaN
+// argument.
+var ErrNaN = errors.New("goarith: comparison with NaN")
+
+// cmpErr returns a.Cmp(b) and nil, or 0 and ErrNaN if a or b is NaN.
+func cmpErr(a, b Number) (int, error) {
+	if a.IsNaN() || b.IsNaN() {
+		return 0, ErrNaN
+	}
+	return a.Cmp(b), nil
+}
+
+// CmpErr methods
+
+// CmpErr returns a.Cmp(b) and nil, or 0 and ErrNaN if b is NaN.
+// An integer is never NaN itself, but its comparison with NaN is
+// reported as an error all the same, as for Float64.
+func (a Int32) CmpErr(b Number) (int, error) {
+	return cmpErr(a, b)
+}
+
+func (a Int64) CmpErr(b Number) (int, error) {
+	return cmpErr(a, b)
+}
+
+// CmpErr returns a.Cmp(b) and nil, or 0 and ErrNaN if a or b is NaN,
+// instead of 0 and no error as if they were equal.
+func (a Float64) CmpErr(b Number) (int, error) {
+	return cmpErr(a, b)
+}
+
+func (a *BigInt) CmpErr(b Number) (int, error) {
+	return cmpErr(a, b)
+}
+
+// NumberSlice attaches the methods of sort.Interface to []Number,
+// sorting in increasing order by CmpTotal.  Thus NaNs with the sign bit
+// come first and the other NaNs come last.
+type NumberSlice []Number
+
+func (p NumberSlice) Len() int           { return len(p) }
+func (p NumberSlice) Less(i, j int) bool { return p[i].CmpTotal(p[j]) < 0 }
+func (p NumberSlice) Swap(i, j int)      { p[i], p[j] = p[j], p[i] }
+
+// Sort sorts ns in increasing order by CmpTotal.
+func Sort(ns []Number) {
+	sort.Sort(NumberSlice(ns))
+}
+
+// SortStable sorts ns in increasing order by CmpTotal, keeping the
+// original order of equal elements, e.g. Int32(1) and Float64(1).
+func SortStable(ns []Number) {
+	sort.Stable(NumberSlice(ns))
+}
+
+// Compare returns a.CmpTotal(b).  It can be passed to slices.SortFunc
+// directly, e.g. slices.SortFunc(ns, goarith.Compare).
+func Compare(a, b Number) int {
+	return a.CmpTotal(b)
+}
+
+// Identical reports whether a and b have the same concrete type and the
+// same value, e.g. it returns false for Int32(5) and Int64(5), which are
+// equal by Cmp.  Values are compared by CmpTotal, so -0.0 and 0.0 are
+// not identical while NaN is identical to NaN of the same sign.
+func Identical(a, b Number) bool {
+	return reflect.TypeOf(a) == reflect.TypeOf(b) && a.CmpTotal(b) == 0
+}
+
+// ApproxEqual reports whether a and b are close to each other, i.e.
+// |a - b| <= max(relTol * max(|a|, |b|), absTol), where a and b are
+// converted into float64.  If a or b is finite but beyond the range of
+// float64, they are compared exactly as big.Rat instead.  Equal
+// infinities are close; NaN is close to nothing.
+func ApproxEqual(a, b Number, relTol, absTol float64) bool {
+	x, y := float64(asFloat64(a)), float64(asFloat64(b))
+	if (math.IsInf(x, 0) && !a.IsInf(0)) || (math.IsInf(y, 0) && !b.IsInf(0)) {
+		r, s := ToBigRat(a), ToBigRat(b)
+		if r == nil || s == nil {
+			return false // The other is infinite or NaN.
+		}
+		return approxEqualRat(r, s, relTol, absTol)
+	} else if x == y {
+		return true
+	} else if math.IsInf(x, 0) || math.IsInf(y, 0) {
+		return false // NaN falls through to the comparison below.
+	}
+	tol := math.Max(relTol*math.Max(math.Abs(x), math.Abs(y)), absTol)
+	return math.Abs(x-y) <= tol
+}
+
+// approxEqualRat is the same as ApproxEqual for finite r and s, which
+// may be modified.
+func approxEqualRat(r, s *big.Rat, relTol, absTol float64) bool {
+	if math.IsNaN(relTol) || math.IsNaN(absTol) {
+		return false
+	} else if math.IsInf(relTol, 1) || math.IsInf(absTol, 1) {
+		return true
+	}
+	d := new(big.Rat).Sub(r, s)
+	d.Abs(d)
+	m := r.Abs(r)
+	if s.Abs(s).Cmp(m) > 0 {
+		m = s
+	}
+	// d <= max(p, q) if and only if d <= p or d <= q.
+	if !math.IsInf(relTol, -1) && d.Cmp(m.Mul(m, new(big.Rat).SetFloat64(relTol))) <= 0 {
+		return true
+	}
+	return !math.IsInf(absTol, -1) && d.Cmp(new(big.Rat).SetFloat64(absTol)) <= 0
+}
+
+// Clamp returns lo if x < lo, hi if x > hi, and x otherwise, keeping the
+// concrete type of the returned value.  If x is NaN, it returns x.
+// It panics if lo > hi.
+func Clamp(x, lo, hi Number) Number {
+	if lo.Cmp(hi) > 0 {
+		panic(fmt.Sprintf("Clamp(%s, %s, %s): lo > hi", x.String(), lo.String(), hi.String()))
+	}
+	if x.Cmp(lo) < 0 {
+		return lo
+	} else if x.Cmp(hi) > 0 {
+		return hi
+	}
+	return x
+}
+
+// InRange reports whether lo <= x <= hi by Cmp, which is exact between
+// any concrete types.  It returns false if x, lo or hi is NaN, and also
+// if lo > hi.
+func InRange(x, lo, hi Number) bool {
+	if x.IsNaN() || lo.IsNaN() || hi.IsNaN() {
+		return false
+	}
+	return lo.Cmp(x) <= 0 && x.Cmp(hi) <= 0
+}
+
+// InRangeExclusive is the same as InRange except that it excludes the
+// bounds, i.e. it reports whether lo < x < hi.
+func InRangeExclusive(x, lo, hi Number) bool {
+	if x.IsNaN() || lo.IsNaN() || hi.IsNaN() {
+		return false
+	}
+	return lo.Cmp(x) < 0 && x.Cmp(hi) < 0
+}
@@ -0,0 +1,277 @@
+package goarith
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func ExampleFloat64_CmpTotal() {
+	nan := Float64(math.NaN())
+	negNaN := Float64(math.Copysign(math.NaN(), -1))
+	negZero := Float64(math.Copysign(0, -1))
+	fmt.Println(nan.Cmp(nan), nan.CmpTotal(nan))
+	fmt.Println(nan.Cmp(Int32(1)), nan.CmpTotal(Int32(1)))
+	fmt.Println(negNaN.CmpTotal(Float64(math.Inf(-1))))
+	fmt.Println(negZero.Cmp(Int32(0)), negZero.CmpTotal(Int32(0)))
+	fmt.Println(Float64(0).CmpTotal(Int32(0)))
+	// Output:
+	// 0 0
+	// 0 1
+	// -1
+	// 0 -1
+	// 0
+}
+
+func TestCmpTotalSort(t *testing.T) {
+	x, _ := new(big.Int).SetString("100000000000000000000", 10)
+	want := []Number{
+		Float64(math.Copysign(math.NaN(), -1)),
+		Float64(math.Inf(-1)),
+		Int64(-1 << 40),
+		Float64(-1.5),
+		Float64(math.Copysign(0, -1)),
+		Int32(0),
+		Float64(0.25),
+		Int32(3),
+		(*BigInt)(x),
+		Float64(math.Inf(1)),
+		Float64(math.NaN()),
+	}
+	for _, perm := range [][]int{
+		{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
+		{10, 9, 8, 7, 6, 5, 4, 3, 2, 1, 0},
+		{5, 10, 0, 4, 9, 1, 8, 2, 7, 3, 6},
+		{3, 7, 10, 1, 5, 0, 9, 6, 2, 8, 4},
+	} {
+		a := make([]Number, len(perm))
+		for i, j := range perm {
+			a[i] = want[j]
+		}
+		sort.Slice(a, func(i, j int) bool { return a[i].CmpTotal(a[j]) < 0 })
+		for i := range a {
+			if a[i].CmpTotal(want[i]) != 0 || a[i].String() != want[i].String() {
+				t.Errorf("%v: a[%d] = %s, want %s", perm, i, a[i], want[i])
+			}
+		}
+	}
+}
+
+func ExampleFloat64_CmpErr() {
+	nan := Float64(math.NaN())
+	fmt.Println(Float64(1.5).CmpErr(Int32(2)))
+	fmt.Println(nan.CmpErr(Float64(1)))
+	fmt.Println(Int32(1).CmpErr(nan))
+	fmt.Println((*BigInt)(big.NewInt(1)).CmpErr(Int64(2)))
+	fmt.Println((*BigInt)(big.NewInt(1)).CmpErr(nan))
+	fmt.Println(nan.Cmp(Float64(1)))
+	// Output:
+	// -1 <nil>
+	// 0 goarith: comparison with NaN
+	// 0 goarith: comparison with NaN
+	// -1 <nil>
+	// 0 goarith: comparison with NaN
+	// 0
+}
+
+func TestCmpErr(t *testing.T) {
+	x, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	d, _ := ParseDecimal("-0.25")
+	ns := []Number{Int32(-3), Int64(1 << 40), Float64(0.5), Float64(math.Inf(-1)),
+		Float64(math.Copysign(0, -1)), (*BigInt)(x), NewBigFloat(Float64(2.5), 0), d}
+	nan := Float64(math.NaN())
+	for _, a := range ns {
+		e := a.(interface {
+			CmpErr(Number) (int, error)
+		})
+		for _, b := range ns {
+			if c, err := e.CmpErr(b); c != a.Cmp(b) || err != nil {
+				t.Errorf("%s.CmpErr(%s) = %d, %v; want %d", a, b, c, err, a.Cmp(b))
+			}
+		}
+		if _, err := e.CmpErr(nan); err != ErrNaN {
+			t.Errorf("%s.CmpErr(NaN): err = %v", a, err)
+		}
+		if _, err := nan.CmpErr(a); err != ErrNaN {
+			t.Errorf("NaN.CmpErr(%s): err = %v", a, err)
+		}
+	}
+}
+
+func ExampleSort() {
+	x, _ := new(big.Int).SetString("-100000000000000000000", 10)
+	a := []Number{Float64(2.5), Int64(1 << 40), Float64(math.NaN()),
+		(*BigInt)(x), Int32(-3), Float64(-1e300), Int32(2)}
+	Sort(a)
+	for _, n := range a[:len(a)-1] {
+		fmt.Printf("%s ", n.String())
+	}
+	fmt.Println(math.IsNaN(float64(a[len(a)-1].(Float64))))
+	// Output:
+	// -1e+300 -100000000000000000000 -3 2 2.5 1099511627776 true
+}
+
+func ExampleSortStable() {
+	a := []Number{Float64(1), Int32(2), Int64(1), Float64(2),
+		(*BigInt)(big.NewInt(1)), Int32(1)}
+	SortStable(a)
+	for _, n := range a {
+		fmt.Printf("%T(%s) ", n, n.String())
+	}
+	fmt.Println()
+	// Output:
+	// goarith.Float64(1.0) goarith.Int64(1) *goarith.BigInt(1) goarith.Int32(1) goarith.Int32(2) goarith.Float64(2.0)
+}
+
+func ExampleCompare() {
+	a := []Number{Float64(math.NaN()), Float64(2.5), Int32(2),
+		Float64(math.Copysign(math.NaN(), -1)), Float64(math.Copysign(0, -1)),
+		Int64(-1 << 40), Int32(0), Float64(math.Inf(1))}
+	sort.Slice(a, func(i, j int) bool { return Compare(a[i], a[j]) < 0 })
+	fmt.Println(a)
+	fmt.Println(math.Signbit(float64(a[0].(Float64))))
+	// Output:
+	// [NaN -1099511627776 -0.0 0 2 2.5 +Inf NaN]
+	// true
+}
+
+func ExampleClamp() {
+	for _, x := range []Number{Int32(-5), Float64(0.5), Int64(1 << 40),
+		Float64(math.NaN())} {
+		c := Clamp(x, Int32(0), Float64(10))
+		fmt.Printf("%T %s\n", c, c)
+	}
+	// Output:
+	// goarith.Int32 0
+	// goarith.Float64 0.5
+	// goarith.Float64 10.0
+	// goarith.Float64 NaN
+}
+
+func TestClampPanics(t *testing.T) {
+	x, _ := new(big.Int).SetString("100000000000000000000", 10)
+	if c := Clamp((*BigInt)(x), Int32(0), Int64(1<<40)); c != Int64(1<<40) {
+		t.Errorf("Clamp = %T %s", c, c)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("Clamp(0, 2, 1) did not panic")
+		}
+	}()
+	Clamp(Int32(0), Int32(2), Float64(1))
+}
+
+func ExampleInRange() {
+	x, _ := new(big.Int).SetString("100000000000000000000", 10)
+	for _, n := range []Number{Int32(0), Float64(0.5), Int64(1 << 40), Float64(1 << 40),
+		(*BigInt)(x), Float64(math.NaN()), Float64(math.Inf(-1))} {
+		fmt.Println(n, InRange(n, Float64(0), Int64(1<<40)),
+			InRangeExclusive(n, Float64(0), Int64(1<<40)))
+	}
+	fmt.Println(InRange(Int32(1), Int32(2), Int32(0)), InRange(Int32(1), Int32(0), Float64(math.NaN())))
+	// Output:
+	// 0 true false
+	// 0.5 true true
+	// 1099511627776 true false
+	// 1.099511627776e+12 true false
+	// 100000000000000000000 false false
+	// NaN false false
+	// -Inf false false
+	// false false
+}
+
+func TestInRange(t *testing.T) {
+	d, _ := ParseDecimal("0.10")
+	f := Float64(0.1) // slightly greater than 0.10
+	if InRange(f, Int32(0), d) || !InRangeExclusive(d, Int32(0), f) {
+		t.Errorf("InRange does not compare %s and %s exactly", f, d)
+	}
+	for _, x := range []Number{Int32(3), f, d, NewBigFloat(f, 0)} {
+		if !InRange(x, x, x) || InRangeExclusive(x, x, x) {
+			t.Errorf("InRange(%s, %s, %s) failed", x, x, x)
+		}
+	}
+}
+
+func ExampleIdentical() {
+	nan := Float64(math.NaN())
+	fmt.Println(Identical(Int32(5), Int32(5)), Identical(Int32(5), Int64(5)),
+		Identical(Int32(5), Float64(5)), Identical(nan, nan),
+		Identical(Float64(0), Float64(math.Copysign(0, -1))))
+	// Output:
+	// true false false true false
+}
+
+func TestIdentical(t *testing.T) {
+	// (*BigInt)(big.NewInt(5)) is equal to Int32(5) but should have been
+	// reduced to it.
+	unreduced := (*BigInt)(big.NewInt(5))
+	for _, c := range []struct {
+		a, b Number
+		want bool
+	}{
+		{unreduced, Int32(5), false},
+		{unreduced, (*BigInt)(big.NewInt(5)), true},
+		{Int64(3).Add(Int32(2)), Int32(5), true},
+		{Int64(math.MaxInt32).Add(Int32(1)), Int64(math.MaxInt32 + 1), true},
+		{NewBigFloat(Int32(1), 0), NewBigFloat(Int32(1), 200), true},
+		{NewBigFloat(Int32(1), 0), Float64(1), false},
+	} {
+		if got := Identical(c.a, c.b); got != c.want {
+			t.Errorf("Identical(%T %s, %T %s) = %t", c.a, c.a, c.b, c.b, got)
+		}
+	}
+}
+
+func ExampleApproxEqual() {
+	third := Int32(1).RQuo(Int32(3))
+	fmt.Println(ApproxEqual(third.Mul(Int32(3)), Int32(1), 1e-9, 0),
+		ApproxEqual(Float64(1.001), Int32(1), 1e-9, 0),
+		ApproxEqual(Float64(1.001), Int32(1), 1e-2, 0),
+		ApproxEqual(Float64(1e-12), Int32(0), 1e-9, 0),
+		ApproxEqual(Float64(1e-12), Int32(0), 1e-9, 1e-10))
+	// Output:
+	// true false true false true
+}
+
+func TestApproxEqual(t *testing.T) {
+	big1, _ := new(big.Int).SetString("1"+strings.Repeat("0", 30), 10)
+	big2 := new(big.Int).Add(big1, big.NewInt(1e15))
+	inf, nan := Float64(math.Inf(1)), Float64(math.NaN())
+	e400 := new(big.Int).Exp(big.NewInt(10), big.NewInt(400), nil)
+	e400x2 := new(big.Int).Lsh(e400, 1)
+	for _, c := range []struct {
+		a, b           Number
+		relTol, absTol float64
+		want           bool
+	}{
+		{(*BigInt)(big1), (*BigInt)(big2), 1e-9, 0, true},
+		{(*BigInt)(big1), (*BigInt)(big2), 1e-18, 0, false},
+		{(*BigInt)(big1), Float64(1e30), 0, 0, true},
+		{Int64(math.MaxInt64), Float64(math.MaxInt64), 0, 0, true},
+		{inf, inf, 0, 0, true},
+		{inf, -inf, 1, 1, false},
+		{inf, Float64(math.MaxFloat64), 1, 1, false},
+		{nan, nan, 1, 1, false},
+		{nan, Int32(0), 1, 1, false},
+		{Int32(-1), Int32(1), 0, 2, true},
+		{(*BigInt)(e400), (*BigInt)(e400x2), 0, 0, false},
+		{(*BigInt)(e400), (*BigInt)(e400x2), 0.5, 0, true},
+		{(*BigInt)(e400), (*BigInt)(e400x2), 0.49, 0, false},
+		{(*BigInt)(e400), (*BigInt)(e400).Inc(), 1e-300, 0, true},
+		{(*BigInt)(e400), (*BigInt)(e400).Inc(), 0, 0.5, false},
+		{(*BigInt)(e400), (*BigInt)(e400).Inc(), 0, 1, true},
+		{(*BigInt)(e400), Float64(math.MaxFloat64), 0.5, 0, false},
+		{(*BigInt)(e400), Float64(math.MaxFloat64), 1, 0, true},
+		{(*BigInt)(e400), inf, 1, 1, false},
+		{(*BigInt)(e400), nan, 1, 1, false},
+	} {
+		if got := ApproxEqual(c.a, c.b, c.relTol, c.absTol); got != c.want {
+			t.Errorf("ApproxEqual(%s, %s, %g, %g) = %t",
+				c.a, c.b, c.relTol, c.absTol, got)
+		}
+	}
+}
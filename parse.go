@@ -0,0 +1,295 @@
+package goarith
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// parseError returns an error reporting that fn failed to parse s
+// because of err, which is strconv.ErrSyntax or strconv.ErrRange.
+func parseError(fn, s string, err error) error {
+	return fmt.Errorf("goarith.%s: parsing %q: %w", fn, s, err)
+}
+
+// isDigit returns whether c is a decimal digit.
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+// isDecimalInteger returns whether s consists of an optional sign and
+// one or more decimal digits.
+func isDecimalInteger(s string) bool {
+	if s != "" && (s[0] == '+' || s[0] == '-') {
+		s = s[1:]
+	}
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if !isDigit(s[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// parseNumber parses s as a decimal integer or a floating-point number
+// and returns the narrowest Number.  fn is the function name to be
+// reported in errors.
+func parseNumber(s string, fn string) (Number, error) {
+	if isDecimalInteger(s) {
+		z, ok := new(big.Int).SetString(s, 10)
+		if !ok {
+			return nil, parseError(fn, s, strconv.ErrSyntax)
+		}
+		return (*BigInt)(z).reduce(), nil
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		if e, ok := err.(*strconv.NumError); ok {
+			err = e.Err
+		}
+		return nil, parseError(fn, s, err)
+	}
+	return Float64(f), nil
+}
+
+// stripUnderscores returns s without underscores and true if every
+// underscore in s is placed between two decimal digits as in Go
+// literals.  Otherwise it returns "" and false.
+func stripUnderscores(s string) (string, bool) {
+	if !strings.Contains(s, "_") {
+		return s, true
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '_' {
+			if i == 0 || i == len(s)-1 || !isDigit(s[i-1]) || !isDigit(s[i+1]) {
+				return "", false
+			}
+		} else {
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String(), true
+}
+
+// ParseNumber parses s as a decimal integer or a floating-point number
+// and returns the narrowest Number: an Int32, Int64 or *BigInt for an
+// integer and a Float64 otherwise.
+// As in Go literals, s may contain underscores between digits, e.g.
+// "1_000_000".  An underscore must be placed between two decimal digits;
+// in particular, it cannot be leading, trailing, doubled or adjacent to
+// the sign, the decimal point or the exponent.
+func ParseNumber(s string) (Number, error) {
+	t, ok := stripUnderscores(s)
+	if !ok {
+		return nil, parseError("ParseNumber", s, strconv.ErrSyntax)
+	}
+	n, err := parseNumber(t, "ParseNumber")
+	if err != nil {
+		return nil, parseError("ParseNumber", s, errors.Unwrap(err))
+	}
+	return n, nil
+}
+
+// ParseNumberStrict is the same as ParseNumber except that, if
+// allowFloat is false, it accepts only decimal integers and reports
+// strconv.ErrSyntax for a floating-point number such as "1.5", "1e3",
+// "Inf" or "NaN".  A large integer is parsed into a *BigInt.
+func ParseNumberStrict(s string, allowFloat bool) (Number, error) {
+	t, ok := stripUnderscores(s)
+	if !ok || !(allowFloat || isDecimalInteger(t)) {
+		return nil, parseError("ParseNumberStrict", s, strconv.ErrSyntax)
+	}
+	n, err := parseNumber(t, "ParseNumberStrict")
+	if err != nil {
+		return nil, parseError("ParseNumberStrict", s, errors.Unwrap(err))
+	}
+	return n, nil
+}
+
+// ParseBigIntPrefixed parses s as an integer literal of Go with an
+// optional sign and returns the narrowest of Int32, Int64 and *BigInt.
+// The base is determined by the prefix of s: "0x" or "0X" for 16, "0o"
+// or "0O" for 8, "0b" or "0B" for 2, and 10 otherwise, as
+// big.Int.SetString(s, 0) does.  Note that a leading "0" alone also
+// means 8, e.g. "017" is 15.  As in Go literals, s may contain
+// underscores between digits, e.g. "0x_FF" or "1_000".
+func ParseBigIntPrefixed(s string) (Number, error) {
+	z, ok := new(big.Int).SetString(s, 0)
+	if !ok {
+		return nil, parseError("ParseBigIntPrefixed", s, strconv.ErrSyntax)
+	}
+	return (*BigInt)(z).reduce(), nil
+}
+
+// ParseLocale parses s, which is written with decimalSep as the decimal
+// separator and groupSep as the digit group separator, into the
+// narrowest Number.
+// For example, ParseLocale("1.234,56", ',', '.') returns 1234.56.
+// Neither '.' nor '_' is accepted unless it is decimalSep or groupSep.
+func ParseLocale(s string, decimalSep, groupSep rune) (Number, error) {
+	var b strings.Builder
+	for _, r := range s {
+		switch r {
+		case groupSep:
+			// skip it
+		case decimalSep:
+			b.WriteByte('.')
+		case '.', '_':
+			return nil, parseError("ParseLocale", s, strconv.ErrSyntax)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	n, err := parseNumber(b.String(), "ParseLocale")
+	if err != nil {
+		return nil, parseError("ParseLocale", s, errors.Unwrap(err))
+	}
+	return n, nil
+}
+
+// errZeroDenominator is reported by ParseRat and ParseBigRat for "n/0".
+var errZeroDenominator = errors.New("zero denominator")
+
+// ParseRat parses s as a fraction "n/d" of two decimal integers, either
+// of which may have a sign, e.g. "3/4" or "-6/4".  If d divides n, it
+// returns the quotient as the narrowest of Int32, Int64 and *BigInt,
+// e.g. Int32(2) for "8/4".  If the reduced denominator has no prime
+// factors other than 2 and 5, it returns the exact *Decimal, e.g. 1.5
+// for "6/4".  Otherwise the fraction has no exact Number, and ParseRat
+// returns it rounded to the nearest Float64, losing precision, e.g.
+// 0.3333333333333333 for "1/3"; use ParseBigRat for the exact fraction.
+// As in ParseNumber, n and d may contain underscores between digits,
+// e.g. "1_000/4".  If s has no '/', ParseRat parses it as ParseNumber
+// does.
+func ParseRat(s string) (Number, error) {
+	return parseRat(s, "ParseRat")
+}
+
+// ParseBigRat parses s as a fraction "n/d" or an integer "n" of decimal
+// integers, either of which may have a sign, and returns the exact
+// reduced fraction, e.g. 1/3 for "2/6".  As in ParseNumber, n and d may
+// contain underscores between digits, e.g. "1_000/3".
+func ParseBigRat(s string) (*big.Rat, error) {
+	return parseBigRat(s, "ParseBigRat")
+}
+
+// parseBigRat implements ParseBigRat.  fn is the function name to be
+// reported in errors.
+func parseBigRat(s string, fn string) (*big.Rat, error) {
+	ns, ds := s, "1"
+	if i := strings.IndexByte(s, '/'); i >= 0 {
+		ns, ds = s[:i], s[i+1:]
+	}
+	ns, ok1 := stripUnderscores(ns)
+	ds, ok2 := stripUnderscores(ds)
+	if !ok1 || !ok2 || !isDecimalInteger(ns) || !isDecimalInteger(ds) {
+		return nil, parseError(fn, s, strconv.ErrSyntax)
+	}
+	n, _ := new(big.Int).SetString(ns, 10)
+	d, _ := new(big.Int).SetString(ds, 10)
+	if d.Sign() == 0 {
+		return nil, parseError(fn, s, errZeroDenominator)
+	}
+	return new(big.Rat).SetFrac(n, d), nil
+}
+
+// parseRat implements ParseRat and Parse.  fn is the function name to be
+// reported in errors.
+func parseRat(s string, fn string) (Number, error) {
+	if strings.IndexByte(s, '/') < 0 {
+		n, err := ParseNumber(s)
+		if err != nil {
+			return nil, parseError(fn, s, errors.Unwrap(err))
+		}
+		return n, nil
+	}
+	r, err := parseBigRat(s, fn)
+	if err != nil {
+		return nil, err
+	}
+	if r.IsInt() {
+		return (*BigInt)(r.Num()).reduce(), nil
+	}
+	if d, ok := ratDecimal(r); ok {
+		return d, nil
+	}
+	f, _ := r.Float64()
+	return Float64(f), nil
+}
+
+// Parse parses s as a decimal integer, a fraction, a decimal number or
+// a floating-point number, in this order of precedence, and returns the
+// most exact Number for it:
+//
+//   - an integer such as "10" or "-1_000" is parsed into the narrowest
+//     of Int32, Int64 and *BigInt, never into a Float64;
+//   - a fraction "n/d" such as "6/4" is parsed as ParseRat does, i.e.
+//     into an integer if d divides n, into an exact *Decimal if the
+//     reduced d has only the prime factors 2 and 5, and into a Float64
+//     with a rounding error otherwise;
+//   - a decimal number such as "0.25" or "1_000.5" is parsed exactly by
+//     ParseDecimal into a *Decimal, or into the narrowest integer if it
+//     has no fractional digits, e.g. "1e3";
+//   - anything else is parsed into a Float64 as ParseNumber does, e.g.
+//     "0x1p4", "Inf", "NaN" and "-0.0", whose sign only a Float64 keeps.
+//
+// The error wraps strconv.ErrSyntax or strconv.ErrRange, or reports a
+// zero denominator.
+func Parse(s string) (Number, error) {
+	if strings.IndexByte(s, '/') < 0 {
+		if t, ok := stripUnderscores(s); ok {
+			d, err := ParseDecimal(t)
+			if err == nil && (d.coef.Sign() != 0 || t[0] != '-') {
+				return d.reduceScale(), nil
+			}
+		}
+	}
+	return parseRat(s, "Parse")
+}
+
+// Value holds a Number and implements fmt.Scanner so that a Number can
+// be read by fmt.Sscan, fmt.Fscanf and so on:
+//
+//	var v goarith.Value
+//	fmt.Sscan("123456789012345678901234567890", &v)
+//	n := v.Number // a *BigInt
+//
+// It accepts the verbs %v, %d and %g.  The token is parsed by
+// ParseNumber into the narrowest Number; %d reads only an integer.
+type Value struct {
+	Number
+}
+
+// Scan implements fmt.Scanner.
+func (v *Value) Scan(state fmt.ScanState, verb rune) error {
+	switch verb {
+	case 'v', 'd', 'g':
+	default:
+		return fmt.Errorf("goarith.Value.Scan: bad verb %%%c", verb)
+	}
+	state.SkipSpace()
+	tok, err := state.Token(false, func(r rune) bool {
+		switch {
+		case '0' <= r && r <= '9', r == '+', r == '-', r == '_':
+			return true
+		case r == '.', r == 'e', r == 'E':
+			return verb != 'd'
+		}
+		return false
+	})
+	if err != nil {
+		return err
+	}
+	n, err := ParseNumber(string(tok))
+	if err != nil {
+		return err
+	}
+	v.Number = n
+	return nil
+}
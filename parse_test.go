@@ -0,0 +1,299 @@
+package goarith
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+)
+
+func ExampleParseLocale() {
+	a, err := ParseLocale("1.234,56", ',', '.')
+	fmt.Printf("%T %s %v\n", a, a.String(), err)
+	a, err = ParseLocale("-1.234.567", ',', '.')
+	fmt.Printf("%T %s %v\n", a, a.String(), err)
+	a, err = ParseLocale("12 345 678 901 234 567 890", ',', ' ')
+	fmt.Printf("%T %s %v\n", a, a.String(), err)
+	a, err = ParseLocale("1,234.56", '.', ',')
+	fmt.Printf("%T %s %v\n", a, a.String(), err)
+	_, err = ParseLocale("1.5", ',', ' ')
+	fmt.Println(err)
+	_, err = ParseLocale("1,2,3", ',', '.')
+	fmt.Println(err)
+	// Output:
+	// goarith.Float64 1234.56 <nil>
+	// goarith.Int32 -1234567 <nil>
+	// *goarith.BigInt 12345678901234567890 <nil>
+	// goarith.Float64 1234.56 <nil>
+	// goarith.ParseLocale: parsing "1.5": invalid syntax
+	// goarith.ParseLocale: parsing "1,2,3": invalid syntax
+}
+
+func TestParseLocale(t *testing.T) {
+	for _, c := range []struct {
+		s                    string
+		decimalSep, groupSep rune
+		want                 string // want is "%T %s" of the result or the error.
+	}{
+		{"1_000", ',', '.', `goarith.ParseLocale: parsing "1_000": invalid syntax`},
+		{"1_000,5", ',', '.', `goarith.ParseLocale: parsing "1_000,5": invalid syntax`},
+		{"1.5", ',', '_', `goarith.ParseLocale: parsing "1.5": invalid syntax`},
+		{"1_000", '.', '_', "goarith.Int32 1000"},
+		{"1_000.25", '.', '_', "goarith.Float64 1000.25"},
+		{"1_000,25", ',', '_', "goarith.Float64 1000.25"},
+		{"1.000", ',', '.', "goarith.Int32 1000"},
+	} {
+		n, err := ParseLocale(c.s, c.decimalSep, c.groupSep)
+		var got string
+		if err != nil {
+			got = err.Error()
+		} else {
+			got = fmt.Sprintf("%T %s", n, n)
+		}
+		if got != c.want {
+			t.Errorf("ParseLocale(%q, %q, %q) = %s, want %s",
+				c.s, c.decimalSep, c.groupSep, got, c.want)
+		}
+	}
+}
+
+func ExampleParseNumber() {
+	for _, s := range []string{
+		"1_000_000", "-2_147_483_649", "123_456_789_012_345_678_901",
+		"1_000.000_1", "1e1_0", "12", "0.5",
+	} {
+		a, err := ParseNumber(s)
+		fmt.Printf("%T %s %v\n", a, a.String(), err)
+	}
+	// Output:
+	// goarith.Int32 1000000 <nil>
+	// goarith.Int64 -2147483649 <nil>
+	// *goarith.BigInt 123456789012345678901 <nil>
+	// goarith.Float64 1000.0001 <nil>
+	// goarith.Float64 1e+10 <nil>
+	// goarith.Int32 12 <nil>
+	// goarith.Float64 0.5 <nil>
+}
+
+func TestParseNumberUnderscores(t *testing.T) {
+	for _, s := range []string{
+		"_1", "1_", "1__000", "-_1", "_-1", "+_1", "1_.5", "1._5",
+		"1_e5", "1e_5", "1e+_5", "1_000_", "_", "1._",
+	} {
+		if a, err := ParseNumber(s); err == nil {
+			t.Errorf("ParseNumber(%q) = %s, want error", s, a)
+		} else if !errors.Is(err, strconv.ErrSyntax) {
+			t.Errorf("ParseNumber(%q): %v, want ErrSyntax", s, err)
+		}
+	}
+	_, err := ParseNumber("1e1_000")
+	if !errors.Is(err, strconv.ErrRange) {
+		t.Errorf("ParseNumber(\"1e1_000\"): %v, want ErrRange", err)
+	}
+}
+
+func ExampleParseNumberStrict() {
+	for _, s := range []string{"100", "-1_000", "123456789012345678901",
+		"1.5", "1e3", "Inf", "1_.5"} {
+		a, err := ParseNumberStrict(s, false)
+		fmt.Printf("%T %v\n", a, err)
+	}
+	a, err := ParseNumberStrict("1e3", true)
+	fmt.Println(a, err)
+	// Output:
+	// goarith.Int32 <nil>
+	// goarith.Int32 <nil>
+	// *goarith.BigInt <nil>
+	// <nil> goarith.ParseNumberStrict: parsing "1.5": invalid syntax
+	// <nil> goarith.ParseNumberStrict: parsing "1e3": invalid syntax
+	// <nil> goarith.ParseNumberStrict: parsing "Inf": invalid syntax
+	// <nil> goarith.ParseNumberStrict: parsing "1_.5": invalid syntax
+	// 1000.0 <nil>
+}
+
+func ExampleParseBigIntPrefixed() {
+	for _, s := range []string{"0xFF", "0b1010", "0o17", "-0X_7fff_ffff_ffff_ffff_ff",
+		"017", "255", "0x", "0b102", "0o8", "0z1", "1_000", "0xFF.8", "1__0", ""} {
+		a, err := ParseBigIntPrefixed(s)
+		fmt.Println(a, err)
+	}
+	// Output:
+	// 255 <nil>
+	// 10 <nil>
+	// 15 <nil>
+	// -2361183241434822606847 <nil>
+	// 15 <nil>
+	// 255 <nil>
+	// <nil> goarith.ParseBigIntPrefixed: parsing "0x": invalid syntax
+	// <nil> goarith.ParseBigIntPrefixed: parsing "0b102": invalid syntax
+	// <nil> goarith.ParseBigIntPrefixed: parsing "0o8": invalid syntax
+	// <nil> goarith.ParseBigIntPrefixed: parsing "0z1": invalid syntax
+	// 1000 <nil>
+	// <nil> goarith.ParseBigIntPrefixed: parsing "0xFF.8": invalid syntax
+	// <nil> goarith.ParseBigIntPrefixed: parsing "1__0": invalid syntax
+	// <nil> goarith.ParseBigIntPrefixed: parsing "": invalid syntax
+}
+
+func ExampleValue() {
+	var a, b, c Value
+	n, err := fmt.Sscan("123456789012345678901234567890 -42 2.5e3", &a, &b, &c)
+	fmt.Println(n, err)
+	for _, v := range []Value{a, b, c} {
+		fmt.Printf("%T %s\n", v.Number, v.Number)
+	}
+	_, err = fmt.Sscanf("x=1_000_000 y=-7", "x=%d y=%d", &a, &b)
+	fmt.Println(a.Number, b.Number, err)
+	// Output:
+	// 3 <nil>
+	// *goarith.BigInt 123456789012345678901234567890
+	// goarith.Int32 -42
+	// goarith.Float64 2500.0
+	// 1000000 -7 <nil>
+}
+
+func TestValueScan(t *testing.T) {
+	for _, c := range []struct {
+		format, input, want string
+	}{
+		{"%v", "-9223372036854775809", "*goarith.BigInt -9223372036854775809"},
+		{"%d", "18446744073709551616", "*goarith.BigInt 18446744073709551616"},
+		{"%d", "  +9223372036854775807", "goarith.Int64 9223372036854775807"},
+		{"%g", "1e-3", "goarith.Float64 0.001"},
+		{"%g", "7", "goarith.Int32 7"},
+	} {
+		var v Value
+		if _, err := fmt.Sscanf(c.input, c.format, &v); err != nil {
+			t.Errorf("Sscanf(%q, %q): %v", c.input, c.format, err)
+		} else if got := fmt.Sprintf("%T %s", v.Number, v.Number); got != c.want {
+			t.Errorf("Sscanf(%q, %q) = %s, want %s", c.input, c.format, got, c.want)
+		}
+	}
+	for _, c := range []struct{ format, input string }{
+		{"%v", "abc"},
+		{"%v", "1__0"},
+		{"%d", "-"},
+		{"%x", "ff"},
+	} {
+		var v Value
+		if _, err := fmt.Sscanf(c.input, c.format, &v); err == nil {
+			t.Errorf("Sscanf(%q, %q) = %s, want error", c.input, c.format, v.Number)
+		}
+	}
+}
+
+func ExampleParseRat() {
+	for _, s := range []string{"8/4", "6/4", "-6/4", "6/-4", "-6/-4", "1/3",
+		"123456789012345678901234567890/10", "7", "3/0", "3/", "1.5/2"} {
+		n, err := ParseRat(s)
+		if err != nil {
+			fmt.Println(err)
+		} else {
+			fmt.Printf("%T %s %s\n", n, n, ToBigRat(n))
+		}
+	}
+	// Output:
+	// goarith.Int32 2 2/1
+	// *goarith.Decimal 1.5 3/2
+	// *goarith.Decimal -1.5 -3/2
+	// *goarith.Decimal -1.5 -3/2
+	// *goarith.Decimal 1.5 3/2
+	// goarith.Float64 0.3333333333333333 6004799503160661/18014398509481984
+	// *goarith.BigInt 12345678901234567890123456789 12345678901234567890123456789/1
+	// goarith.Int32 7 7/1
+	// goarith.ParseRat: parsing "3/0": zero denominator
+	// goarith.ParseRat: parsing "3/": invalid syntax
+	// goarith.ParseRat: parsing "1.5/2": invalid syntax
+}
+
+func ExampleParseBigRat() {
+	for _, s := range []string{"2/6", "-6/4", "7", "1/3/4", "1/0"} {
+		r, err := ParseBigRat(s)
+		fmt.Println(r, err)
+	}
+	// Output:
+	// 1/3 <nil>
+	// -3/2 <nil>
+	// 7/1 <nil>
+	// <nil> goarith.ParseBigRat: parsing "1/3/4": invalid syntax
+	// <nil> goarith.ParseBigRat: parsing "1/0": zero denominator
+}
+
+func ExampleParse() {
+	for _, s := range []string{"10", "1/4", "0.25", "1/3", "1e3", "0.1e-1", "Inf"} {
+		n, err := Parse(s)
+		fmt.Printf("%T %s %v\n", n, n, err)
+	}
+	// Output:
+	// goarith.Int32 10 <nil>
+	// *goarith.Decimal 0.25 <nil>
+	// *goarith.Decimal 0.25 <nil>
+	// goarith.Float64 0.3333333333333333 <nil>
+	// goarith.Int32 1000 <nil>
+	// *goarith.Decimal 0.01 <nil>
+	// goarith.Float64 +Inf <nil>
+}
+
+func TestParse(t *testing.T) {
+	for _, c := range []struct {
+		s, want string // want is "%T %s" of the result or the error.
+	}{
+		{"10", "goarith.Int32 10"},
+		{"-10", "goarith.Int32 -10"},
+		{"+0", "goarith.Int32 0"},
+		{"-0", "goarith.Int32 0"},
+		{"1_000", "goarith.Int32 1000"},
+		{"4294967296", "goarith.Int64 4294967296"},
+		{"-9223372036854775809", "*goarith.BigInt -9223372036854775809"},
+		{"8/4", "goarith.Int32 2"},
+		{"-8/4", "goarith.Int32 -2"},
+		{"18446744073709551616/4", "goarith.Int64 4611686018427387904"},
+		{"36893488147419103232/2", "*goarith.BigInt 18446744073709551616"},
+		{"1/4", "*goarith.Decimal 0.25"},
+		{"-7/40", "*goarith.Decimal -0.175"},
+		{"1/1024", "*goarith.Decimal 0.0009765625"},
+		{"1/-3", "goarith.Float64 -0.3333333333333333"},
+		{"0/5", "goarith.Int32 0"},
+		{"1_000/4", "goarith.Int32 250"},
+		{"-1_000/1_024", "*goarith.Decimal -0.9765625"},
+		{"1/1_000_000_007", "goarith.Float64 9.99999993e-10"},
+		{"10.0", "*goarith.Decimal 10.0"},
+		{"0.25", "*goarith.Decimal 0.25"},
+		{"0.10", "*goarith.Decimal 0.10"},
+		{"1_000.5", "*goarith.Decimal 1000.5"},
+		{"1.5e-3", "*goarith.Decimal 0.0015"},
+		{"1e20", "*goarith.BigInt 100000000000000000000"},
+		{"1e3", "goarith.Int32 1000"},
+		{"-.5", "*goarith.Decimal -0.5"},
+		{"0x1p4", "goarith.Float64 16.0"},
+		{"Inf", "goarith.Float64 +Inf"},
+		{"-inf", "goarith.Float64 -Inf"},
+		{"NaN", "goarith.Float64 NaN"},
+		{"-0.0", "goarith.Float64 -0.0"},
+		{"", `goarith.Parse: parsing "": invalid syntax`},
+		{" 1", `goarith.Parse: parsing " 1": invalid syntax`},
+		{"1 ", `goarith.Parse: parsing "1 ": invalid syntax`},
+		{"1/0", `goarith.Parse: parsing "1/0": zero denominator`},
+		{"1/2/3", `goarith.Parse: parsing "1/2/3": invalid syntax`},
+		{"/2", `goarith.Parse: parsing "/2": invalid syntax`},
+		{"1.0/2", `goarith.Parse: parsing "1.0/2": invalid syntax`},
+		{"1_/2", `goarith.Parse: parsing "1_/2": invalid syntax`},
+		{"1/_2", `goarith.Parse: parsing "1/_2": invalid syntax`},
+		{"1/2__0", `goarith.Parse: parsing "1/2__0": invalid syntax`},
+		{"abc", `goarith.Parse: parsing "abc": invalid syntax`},
+		{"1e200000", `goarith.Parse: parsing "1e200000": value out of range`},
+		{"1._5", `goarith.Parse: parsing "1._5": invalid syntax`},
+		{"0x10", `goarith.Parse: parsing "0x10": invalid syntax`},
+		{"__1", `goarith.Parse: parsing "__1": invalid syntax`},
+	} {
+		n, err := Parse(c.s)
+		var got string
+		if err != nil {
+			got = err.Error()
+		} else {
+			got = fmt.Sprintf("%T %s", n, n)
+		}
+		if got != c.want {
+			t.Errorf("Parse(%q) = %s, want %s", c.s, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,190 @@
+package goarith
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"math/bits"
+)
+
+// powInt64 returns x**n and true, computed by repeated squaring.
+// It returns false if the result or an intermediate result overflows.
+func powInt64(x int64, n uint64) (int64, bool) {
+	neg := x < 0 && n&1 == 1
+	u := uint64(x)
+	if x < 0 {
+		u = -u
+	}
+	r := uint64(1)
+	for {
+		if n&1 != 0 {
+			hi, lo := bits.Mul64(r, u)
+			if hi != 0 {
+				return 0, false
+			}
+			r = lo
+		}
+		n >>= 1
+		if n == 0 {
+			break
+		}
+		hi, lo := bits.Mul64(u, u) // u will be multiplied into r later.
+		if hi != 0 {
+			return 0, false
+		}
+		u = lo
+	}
+	if neg {
+		if r > 1<<63 {
+			return 0, false
+		}
+		return -int64(r), true // -int64(1<<63) is math.MinInt64.
+	} else if r >= 1<<63 {
+		return 0, false
+	}
+	return int64(r), true
+}
+
+// powBigFloat returns x**n computed by repeated squaring in big.Float
+// of the precision prec.
+func powBigFloat(x *big.Float, n int64, prec uint) *BigFloat {
+	u := uint64(n)
+	if n < 0 {
+		u = -u
+	}
+	z := new(big.Float).SetPrec(prec).SetInt64(1)
+	p := new(big.Float).SetPrec(prec).Set(x)
+	for u != 0 {
+		if u&1 != 0 {
+			z.Mul(z, p)
+		}
+		u >>= 1
+		if u != 0 {
+			p.Mul(p, p)
+		}
+	}
+	if n < 0 {
+		z.Quo(p.SetInt64(1), z)
+	}
+	return (*BigFloat)(z)
+}
+
+// pow returns a**b.  For Int32 and Int64 operands, it computes the
+// result in int64 and promotes it to a big.Int only when it overflows.
+func pow(a, b Number) Number {
+	if prec := bigFloatPrec(a, b); prec != 0 {
+		if n, exact := b.Int64(); exact {
+			if x := toBigFloat(a); x != nil {
+				return powBigFloat(x, n, prec)
+			}
+		}
+	} else if x, ok := fixedInt(a); ok {
+		if n, ok := fixedInt(b); ok && n >= 0 {
+			if r, ok := powInt64(x, uint64(n)); ok {
+				return Int64(r).reduce()
+			}
+		}
+	}
+	if x := toBigInt(a); x != nil {
+		if y := toBigInt(b); y != nil && y.Sign() >= 0 {
+			return (*BigInt)(x.Exp(x, y, nil)).reduce()
+		}
+	}
+	return Float64(math.Pow(float64(asFloat64(a)), float64(asFloat64(b))))
+}
+
+// Pow methods
+
+func (a Int32) Pow(b Number) Number {
+	return pow(a, b)
+}
+
+func (a Int64) Pow(b Number) Number {
+	return pow(a, b)
+}
+
+func (a Float64) Pow(b Number) Number {
+	return pow(a, b)
+}
+
+func (a *BigInt) Pow(b Number) Number {
+	return pow(a, b)
+}
+
+// rootFloor returns floor(m**(1/k)) for m >= 0 and k >= 1 by Newton's
+// iteration from an initial guess not less than the root.
+func rootFloor(m *big.Int, k int) *big.Int {
+	if m.Sign() == 0 || k == 1 {
+		return new(big.Int).Set(m)
+	} else if k >= m.BitLen() {
+		return big.NewInt(1) // 1 <= m < 2**k
+	}
+	x := new(big.Int).Lsh(bigOne, uint((m.BitLen()+k-1)/k))
+	bk, bk1 := big.NewInt(int64(k)), big.NewInt(int64(k-1))
+	t, y := new(big.Int), new(big.Int)
+	for {
+		// y = ((k - 1) * x + m / x**(k - 1)) / k
+		t.Exp(x, bk1, nil)
+		t.Quo(m, t)
+		y.Mul(x, bk1)
+		y.Add(y, t)
+		y.Quo(y, bk)
+		if y.Cmp(x) >= 0 {
+			return x
+		}
+		x, y = y, x
+	}
+}
+
+// IRoot returns the k-th root of an integer n rounded toward -Inf, i.e.
+// the largest integer r such that r**k <= n, and whether r**k == n.
+// For example, IRoot(Int32(27), 3) returns 3 and true, and
+// IRoot(Int32(28), 3) returns 3 and false.  A negative n is allowed only
+// for an odd k, e.g. IRoot(Int32(-28), 3) returns -4 and false.
+// An integral Float64, *BigFloat or *Decimal is taken as the equal
+// integer, e.g. IRoot(Float64(27), 3) returns Int32(3) and true.
+// It panics if n is not an integer, k < 1, or n < 0 and k is even.
+func IRoot(n Number, k int) (Number, bool) {
+	z := integerValue(n)
+	if z == nil || k < 1 || (z.Sign() < 0 && k%2 == 0) {
+		panic(fmt.Sprintf("IRoot(%s, %d)", n.String(), k))
+	}
+	neg := z.Sign() < 0
+	r := rootFloor(z.Abs(z), k)
+	exact := new(big.Int).Exp(r, big.NewInt(int64(k)), nil).Cmp(z) == 0
+	if neg {
+		r.Neg(r)
+		if !exact {
+			r.Sub(r, bigOne)
+		}
+	}
+	return (*BigInt)(r).reduce(), exact
+}
+
+// IsPerfectPower reports whether an integer n equals base**exp for some
+// integers base and exp >= 2.  If so, it returns the largest such exp,
+// i.e. the smallest |base|, e.g. 2 and 6 for 64 rather than 8 and 2 or
+// 4 and 3.  A negative n is tried for odd exps, e.g. -2 and 3 for -8.
+// As special cases, 0 and 1 are returned as 0**2 and 1**2, and -1 as
+// (-1)**3.  It panics if n is not an integer.
+func IsPerfectPower(n Number) (base Number, exp int, ok bool) {
+	z := toBigInt(n)
+	if z == nil {
+		panic(fmt.Sprintf("IsPerfectPower(%s)", n.String()))
+	}
+	switch {
+	case z.Sign() == 0 || z.Cmp(bigOne) == 0:
+		return (*BigInt)(z).reduce(), 2, true
+	case z.CmpAbs(bigOne) == 0:
+		return (*BigInt)(z).reduce(), 3, true
+	}
+	for k := z.BitLen(); k >= 2; k-- {
+		if z.Sign() < 0 && k%2 == 0 {
+			continue
+		}
+		if r, exact := IRoot(n, k); exact {
+			return r, k, true
+		}
+	}
+	return nil, 0, false
+}
@@ -0,0 +1,201 @@
+package goarith
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func ExampleInt32_Pow() {
+	for _, c := range [][2]Number{
+		{Int32(2), Int64(10)},
+		{Int32(-3), Int32(3)},
+		{Int32(2), Int32(40)},
+		{Int64(10), Int32(30)},
+		{Int32(2), Int32(-2)},
+		{Int32(2), Float64(0.5)},
+		{Float64(1.5), Int32(2)},
+		{Int32(0), Int32(0)},
+	} {
+		r := c[0].Pow(c[1])
+		fmt.Printf("%T %s\n", r, r)
+	}
+	// Output:
+	// goarith.Int32 1024
+	// goarith.Int32 -27
+	// goarith.Int64 1099511627776
+	// *goarith.BigInt 1000000000000000000000000000000
+	// goarith.Float64 0.25
+	// goarith.Float64 1.4142135623730951
+	// goarith.Float64 2.25
+	// goarith.Int32 1
+}
+
+func ExampleBigFloat_Pow() {
+	x, _ := new(big.Float).SetPrec(100).SetString("1.1")
+	a := (*BigFloat)(x)
+	fmt.Println(FormatNumber(a.Pow(Int32(100)), 'g', 25))
+	fmt.Println(FormatNumber(a.Pow(Int32(-1)), 'g', 25))
+	// Output:
+	// 13780.61233982227018411834
+	// 0.9090909090909090909090909
+}
+
+func TestPowOverflow(t *testing.T) {
+	minInt64 := Int64(math.MinInt64)
+	for _, c := range []struct {
+		a, b Number
+		want string
+	}{
+		{Int32(2), Int32(30), "goarith.Int32 1073741824"},
+		{Int32(2), Int32(31), "goarith.Int64 2147483648"},
+		{Int32(-2), Int32(31), "goarith.Int32 -2147483648"},
+		{Int32(2), Int32(62), "goarith.Int64 4611686018427387904"},
+		{Int32(2), Int32(63), "*goarith.BigInt 9223372036854775808"},
+		{Int32(-2), Int32(63), "goarith.Int64 -9223372036854775808"},
+		{Int32(-2), Int32(64), "*goarith.BigInt 18446744073709551616"},
+		{Int32(3), Int32(39), "goarith.Int64 4052555153018976267"},
+		{Int32(3), Int32(40), "*goarith.BigInt 12157665459056928801"},
+		{Int64(3037000499), Int32(2), "goarith.Int64 9223372030926249001"},
+		{Int64(3037000500), Int32(2), "*goarith.BigInt 9223372037000250000"},
+		{minInt64, Int32(1), "goarith.Int64 -9223372036854775808"},
+		{minInt64, Int32(2), "*goarith.BigInt 85070591730234615865843651857942052864"},
+		{Int32(-1), Int64(math.MaxInt64), "goarith.Int32 -1"},
+		{Int32(1), (*BigInt)(new(big.Int).Lsh(bigOne, 100)), "goarith.Int32 1"},
+	} {
+		r := c.a.Pow(c.b)
+		if got := fmt.Sprintf("%T %s", r, r); got != c.want {
+			t.Errorf("%s.Pow(%s) = %s, want %s", c.a, c.b, got, c.want)
+		}
+	}
+}
+
+func ExampleIRoot() {
+	x, _ := new(big.Int).SetString("1000000000000000000000000000000000000000000000000000000000001", 10)
+	for _, c := range []struct {
+		n Number
+		k int
+	}{
+		{Int32(27), 3}, {Int32(28), 3}, {Int32(26), 3}, {Int32(-27), 3}, {Int32(-28), 3},
+		{Int32(0), 5}, {Int32(1), 7}, {Int64(math.MaxInt64), 2}, {(*BigInt)(x), 3}, {Int32(7), 1},
+	} {
+		fmt.Println(IRoot(c.n, c.k))
+	}
+	// Output:
+	// 3 true
+	// 3 false
+	// 2 false
+	// -3 true
+	// -4 false
+	// 0 true
+	// 1 true
+	// 3037000499 false
+	// 100000000000000000000 false
+	// 7 true
+}
+
+func TestIRoot(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 500; i++ {
+		k := i%9 + 1
+		m := new(big.Int).Rand(r, new(big.Int).Lsh(bigOne, uint(i%200+1)))
+		root, exact := IRoot((*BigInt)(m).reduce(), k)
+		x := toBigInt(root)
+		bk := big.NewInt(int64(k))
+		lo := new(big.Int).Exp(x, bk, nil)
+		hi := new(big.Int).Exp(x.Add(x, bigOne), bk, nil)
+		if lo.Cmp(m) > 0 || hi.Cmp(m) <= 0 || exact != (lo.Cmp(m) == 0) {
+			t.Errorf("IRoot(%s, %d) = %s, %t", m, k, root, exact)
+		}
+	}
+	for _, c := range []struct {
+		n Number
+		k int
+	}{{Int32(4), 0}, {Int32(-4), 2}, {Float64(8.5), 3}, {Float64(math.Inf(1)), 3},
+		{NewBigFloat(Float64(0.5), 0), 2}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("IRoot(%s, %d) did not panic", c.n, c.k)
+				}
+			}()
+			IRoot(c.n, c.k)
+		}()
+	}
+}
+
+func TestIRootNonIntegerTypes(t *testing.T) {
+	d, _ := ParseDecimal("-27.00")
+	for _, c := range []struct {
+		n     Number
+		k     int
+		want  Number
+		exact bool
+	}{
+		{Float64(27), 3, Int32(3), true},
+		{Float64(1e20), 2, Int64(1e10), true},
+		{NewBigFloat(Float64(28), 0), 3, Int32(3), false},
+		{d, 3, Int32(-3), true},
+		{Int32(5), 1 << 26, Int32(1), false},
+		{Int32(-5), 1<<26 + 1, Int32(-2), false},
+		{Int32(1), 1 << 30, Int32(1), true},
+	} {
+		r, exact := IRoot(c.n, c.k)
+		if !Identical(r, c.want) || exact != c.exact {
+			t.Errorf("IRoot(%s, %d) = %s, %t", c.n, c.k, r, exact)
+		}
+	}
+}
+
+func ExampleIsPerfectPower() {
+	x, _ := new(big.Int).SetString("1"+strings.Repeat("0", 60), 10)
+	for _, n := range []Number{Int32(64), Int32(-8), Int32(-64), Int32(72), Int32(97),
+		Int64(1 << 62), Int64(3486784401), (*BigInt)(x), Int32(0), Int32(1), Int32(-1), Int32(2)} {
+		fmt.Println(IsPerfectPower(n))
+	}
+	// Output:
+	// 2 6 true
+	// -2 3 true
+	// -4 3 true
+	// <nil> 0 false
+	// <nil> 0 false
+	// 2 62 true
+	// 3 20 true
+	// 10 60 true
+	// 0 2 true
+	// 1 2 true
+	// -1 3 true
+	// <nil> 0 false
+}
+
+func TestIsPerfectPowerPrimes(t *testing.T) {
+	for p := int64(2); p < 2000; p++ {
+		if !big.NewInt(p).ProbablyPrime(0) {
+			continue
+		}
+		if b, e, ok := IsPerfectPower(Int64(p)); ok {
+			t.Errorf("IsPerfectPower(%d) = %s, %d, true", p, b, e)
+		}
+		if b, e, ok := IsPerfectPower(Int64(p * p * p)); !ok || e != 3 || b != Int32(p) {
+			t.Errorf("IsPerfectPower(%d**3) = %s, %d, %t", p, b, e, ok)
+		}
+	}
+}
+
+func BenchmarkPowInt64(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		Int32(3).Pow(Int64(20))
+	}
+}
+
+func BenchmarkPowBigIntExp(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		x := big.NewInt(3)
+		(*BigInt)(x.Exp(x, big.NewInt(20), nil)).reduce()
+	}
+}
@@ -0,0 +1,368 @@
+package goarith
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+)
+
+// RoundingMode determines how a quotient is rounded to an integer.
+type RoundingMode byte
+
+const (
+	ToNearestEven RoundingMode = iota // to the nearest; ties to even
+	ToZero                            // toward zero
+	Up                                // toward +Inf
+	Down                              // toward -Inf
+	AwayFromZero                      // away from zero
+	ToNearestAway                     // to the nearest; ties away from zero
+)
+
+// roundFloat rounds f to an integral value according to mode.
+func (mode RoundingMode) roundFloat(f float64) float64 {
+	switch mode {
+	case ToNearestEven:
+		return math.RoundToEven(f)
+	case ToZero:
+		return math.Trunc(f)
+	case Up:
+		return math.Ceil(f)
+	case Down:
+		return math.Floor(f)
+	case AwayFromZero:
+		if f < 0 {
+			return math.Floor(f)
+		}
+		return math.Ceil(f)
+	case ToNearestAway:
+		return math.Round(f)
+	}
+	panic(fmt.Sprintf("unknown rounding mode %d", mode))
+}
+
+// quoRound returns x / y rounded to an integer according to mode.
+// It computes the quotient exactly by big.Int.QuoRem and inspects the
+// remainder to decide the rounding.
+func quoRound(x, y *big.Int, mode RoundingMode) *big.Int {
+	q := new(big.Int)
+	r := new(big.Int)
+	q.QuoRem(x, y, r)
+	if r.Sign() != 0 {
+		neg := x.Sign() != y.Sign() // Is the exact quotient negative?
+		var away bool
+		switch mode {
+		case ToNearestEven:
+			r.Lsh(r.Abs(r), 1)
+			c := r.CmpAbs(y)
+			away = c > 0 || (c == 0 && q.Bit(0) == 1)
+		case ToNearestAway:
+			r.Lsh(r.Abs(r), 1)
+			away = r.CmpAbs(y) >= 0
+		case ToZero:
+			away = false
+		case Up:
+			away = !neg
+		case Down:
+			away = neg
+		case AwayFromZero:
+			away = true
+		default:
+			panic(fmt.Sprintf("unknown rounding mode %d", mode))
+		}
+		if away {
+			if neg {
+				q.Sub(q, bigOne)
+			} else {
+				q.Add(q, bigOne)
+			}
+		}
+	}
+	return q
+}
+
+// quantize rounds a to the nearest multiple of step with ties to even.
+// The result is a *BigFloat if a or step is a *BigFloat, a Float64 if a
+// or step is a Float64, or a *Decimal if a or step is a *Decimal.
+// For a zero step, it returns NaN if a or step is a Float64, and a
+// otherwise.
+func quantize(a, step Number) Number {
+	_, fa := a.(Float64)
+	_, fs := step.(Float64)
+	prec := bigFloatPrec(a, step)
+	x, y := ToBigRat(a), ToBigRat(step)
+	if x == nil || y == nil { // a or step is infinite or NaN.
+		s := asFloat64(step)
+		return Float64(math.RoundToEven(float64(asFloat64(a)/s))) * s
+	}
+	if y.Sign() == 0 {
+		if fa || fs {
+			return Float64(math.NaN()) // as 0 * round(a / 0)
+		}
+		return a
+	}
+	q := new(big.Rat).Quo(x, y)
+	n := quoRound(q.Num(), q.Denom(), ToNearestEven)
+	z := y.Mul(y, new(big.Rat).SetInt(n))
+	if prec != 0 {
+		return (*BigFloat)(new(big.Float).SetPrec(prec).SetRat(z))
+	} else if fa || fs {
+		f, _ := z.Float64()
+		return Float64(f)
+	} else if d, ok := step.(*Decimal); ok {
+		return &Decimal{n.Mul(n, d.coef), d.scale}
+	} else if _, ok := a.(*Decimal); ok {
+		return &Decimal{z.Num(), 0} // z is an integer here.
+	}
+	return (*BigInt)(z.Num()).reduce() // z is an integer here.
+}
+
+// quoRoundInt is the same as quoRound except that it returns the
+// reduced Number.
+func quoRoundInt(x, y *big.Int, mode RoundingMode) Number {
+	return (*BigInt)(quoRound(x, y, mode)).reduce()
+}
+
+// RQuoRound methods
+
+func (a Int32) RQuoRound(b Number, mode RoundingMode) Number {
+	return Int64(a).RQuoRound(b, mode)
+}
+
+func (a Int64) RQuoRound(b Number, mode RoundingMode) Number {
+	switch y := b.(type) {
+	case Int32:
+		return quoRoundInt(big.NewInt(int64(a)), big.NewInt(int64(y)), mode)
+	case Int64:
+		return quoRoundInt(big.NewInt(int64(a)), big.NewInt(int64(y)), mode)
+	case Float64:
+		return Float64(a).RQuoRound(y, mode)
+	case *BigInt:
+		return quoRoundInt(big.NewInt(int64(a)), (*big.Int)(y), mode)
+	case *BigFloat:
+		return quoRoundRat(a, y, mode)
+	case *Decimal:
+		return quoRoundRat(a, y, mode)
+	}
+	panic(fmt.Sprintf("%s.RQuoRound(%s)", a.String(), b.String()))
+}
+
+func (a Float64) RQuoRound(b Number, mode RoundingMode) Number {
+	if _, ok := b.(*BigFloat); ok {
+		return quoRoundRat(a, b, mode)
+	}
+	q := a.RQuo(b)
+	return fromIntegral(mode.roundFloat(float64(q)))
+}
+
+func (a *BigInt) RQuoRound(b Number, mode RoundingMode) Number {
+	switch y := b.(type) {
+	case Int32:
+		return quoRoundInt((*big.Int)(a), big.NewInt(int64(y)), mode)
+	case Int64:
+		return quoRoundInt((*big.Int)(a), big.NewInt(int64(y)), mode)
+	case Float64:
+		return a.toFloat64().RQuoRound(y, mode)
+	case *BigInt:
+		return quoRoundInt((*big.Int)(a), (*big.Int)(y), mode)
+	case *BigFloat:
+		return quoRoundRat(a, y, mode)
+	case *Decimal:
+		return quoRoundRat(a, y, mode)
+	}
+	panic(fmt.Sprintf("%s.RQuoRound(%s)", a.String(), b.String()))
+}
+
+// DivRound returns a / b rounded to an integer according to mode, e.g.
+// DivRound(Int32(5), Int32(2), ToNearestEven) returns 2 and
+// DivRound(Int32(5), Int32(2), ToNearestAway) returns 3.
+// It is the same as a.RQuoRound(b, mode).  If a and b are integers, it
+// decides the rounding exactly by comparing 2*|remainder| with |b|.
+// It panics if a and b are integers and b is zero.
+func DivRound(a, b Number, mode RoundingMode) Number {
+	return a.RQuoRound(b, mode)
+}
+
+// CeilDiv returns ceil(a / b), i.e. a.RQuoRound(b, Up).
+// If a and b are integers, the result is exact for any signs of a and
+// b.  It panics if a and b are integers and b is zero.
+func CeilDiv(a, b Number) Number {
+	return a.RQuoRound(b, Up)
+}
+
+// RoundTo returns n rounded to places decimal digits after the decimal
+// point according to mode, e.g. RoundTo(Float64(2.345), 2, ToNearestAway)
+// returns 2.35.  If places is negative, it rounds n to a multiple of
+// 10**-places, e.g. to the nearest hundred for -2.
+// For a Float64, it multiplies n by 10**places, rounds the product to an
+// integer and divides it back, so the result is subject to the binary
+// representation of n; e.g. 1.005 rounds to 1.0 since it is slightly
+// less than 1.005 in float64.  For the other types, the result is exact:
+// an integer stays an integer, a *BigFloat keeps its precision and a
+// *Decimal gets the scale places.  Infinities and NaN are returned as is.
+func RoundTo(n Number, places int, mode RoundingMode) Number {
+	switch x := n.(type) {
+	case Float64:
+		f := float64(x)
+		if math.IsInf(f, 0) || math.IsNaN(f) {
+			return x
+		}
+		if places >= 0 {
+			p := math.Pow10(places)
+			y := f * p
+			if math.IsInf(y, 0) || math.Abs(y) >= 1<<52 {
+				return x // x has no fractional digits at places.
+			}
+			return Float64(mode.roundFloat(y) / p)
+		}
+		if places < -308 {
+			places = -308 // Avoid 10**-places being +Inf.
+		}
+		p := math.Pow10(-places)
+		return Float64(mode.roundFloat(f/p) * p)
+	case *Decimal:
+		return x.roundTo(toScale(int64(places)), mode)
+	case *BigFloat:
+		if x.IsInf(0) {
+			return x
+		}
+		r, _ := (*big.Float)(x).Rat(nil)
+		z := new(big.Float).SetPrec((*big.Float)(x).Prec())
+		return (*BigFloat)(z.SetRat(roundRat(r, places, mode)))
+	}
+	if places >= 0 {
+		return n // n is an integer.
+	}
+	return (*BigInt)(roundRat(ToBigRat(n), places, mode).Num()).reduce()
+}
+
+// roundRat returns r rounded to places decimal digits according to mode
+// as RoundTo does, reusing r.
+func roundRat(r *big.Rat, places int, mode RoundingMode) *big.Rat {
+	if places >= 0 {
+		p := pow10(int64(places))
+		q := quoRound(new(big.Int).Mul(r.Num(), p), r.Denom(), mode)
+		return r.SetFrac(q, p)
+	}
+	p := pow10(-int64(places))
+	q := quoRound(r.Num(), new(big.Int).Mul(r.Denom(), p), mode)
+	return r.SetInt(q.Mul(q, p))
+}
+
+// floorDivMod returns the floored quotient and the remainder of a and b
+// by adjusting the truncated ones of QuoRem when the remainder is
+// nonzero and its sign differs from that of b.
+func floorDivMod(a, b Number) (Number, Number) {
+	q, r := a.QuoRem(b)
+	if (r.IsNegative() && b.IsPositive()) || (r.IsPositive() && b.IsNegative()) {
+		return q.Dec(), r.Add(b)
+	}
+	return q, r
+}
+
+// FloorDivMod methods
+
+func (a Int32) FloorDivMod(b Number) (Number, Number) {
+	return floorDivMod(a, b)
+}
+
+func (a Int64) FloorDivMod(b Number) (Number, Number) {
+	return floorDivMod(a, b)
+}
+
+func (a Float64) FloorDivMod(b Number) (Number, Number) {
+	return floorDivMod(a, b)
+}
+
+func (a *BigInt) FloorDivMod(b Number) (Number, Number) {
+	return floorDivMod(a, b)
+}
+
+// QuantizeTo methods
+
+func (a Int32) QuantizeTo(step Number) Number {
+	return quantize(a, step)
+}
+
+func (a Int64) QuantizeTo(step Number) Number {
+	return quantize(a, step)
+}
+
+func (a Float64) QuantizeTo(step Number) Number {
+	return quantize(a, step)
+}
+
+func (a *BigInt) QuantizeTo(step Number) Number {
+	return quantize(a, step)
+}
+
+// Trunc methods
+
+func (a Int32) Trunc() Number {
+	return a
+}
+
+func (a Int64) Trunc() Number {
+	return a
+}
+
+func (a Float64) Trunc() Number {
+	return Float64(math.Trunc(float64(a)))
+}
+
+func (a *BigInt) Trunc() Number {
+	return a
+}
+
+// Floor methods
+
+func (a Int32) Floor() Number {
+	return a
+}
+
+func (a Int64) Floor() Number {
+	return a
+}
+
+func (a Float64) Floor() Number {
+	return Float64(math.Floor(float64(a)))
+}
+
+func (a *BigInt) Floor() Number {
+	return a
+}
+
+// Ceil methods
+
+func (a Int32) Ceil() Number {
+	return a
+}
+
+func (a Int64) Ceil() Number {
+	return a
+}
+
+func (a Float64) Ceil() Number {
+	return Float64(math.Ceil(float64(a)))
+}
+
+func (a *BigInt) Ceil() Number {
+	return a
+}
+
+// Round methods
+
+func (a Int32) Round() Number {
+	return a
+}
+
+func (a Int64) Round() Number {
+	return a
+}
+
+func (a Float64) Round() Number {
+	return Float64(math.Round(float64(a)))
+}
+
+func (a *BigInt) Round() Number {
+	return a
+}
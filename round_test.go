@@ -0,0 +1,248 @@
+package goarith
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+)
+
+func ExampleInt64_RQuoRound() {
+	modes := []RoundingMode{ToNearestEven, ToZero, Up, Down, AwayFromZero}
+	for _, a := range []Int64{7, -7, 5, 6} {
+		for _, mode := range modes {
+			fmt.Printf(" %s", a.RQuoRound(Int64(2), mode).String())
+		}
+		fmt.Println()
+	}
+	// Output:
+	//  4 3 4 3 4
+	//  -4 -3 -3 -4 -4
+	//  2 2 3 2 3
+	//  3 3 3 3 3
+}
+
+func ExampleBigInt_RQuoRound() {
+	x, _ := new(big.Int).SetString("100000000000000000001", 10)
+	a := (*BigInt)(x)
+	for _, mode := range []RoundingMode{ToNearestEven, AwayFromZero} {
+		q := a.RQuoRound(Int32(2), mode)
+		fmt.Printf("%T %s\n", q, q.String())
+	}
+	y, _ := new(big.Int).SetString("-300000000000000000002", 10)
+	q := (*BigInt)(y).RQuoRound(Int32(3), Down)
+	fmt.Printf("%T %s\n", q, q.String())
+	// Output:
+	// *goarith.BigInt 50000000000000000000
+	// *goarith.BigInt 50000000000000000001
+	// *goarith.BigInt -100000000000000000001
+}
+
+func ExampleFloat64_RQuoRound() {
+	q := Float64(7).RQuoRound(Int32(2), ToNearestEven)
+	fmt.Printf("%T %s\n", q, q.String())
+	q = Float64(-7.5).RQuoRound(Float64(1), AwayFromZero)
+	fmt.Printf("%T %s\n", q, q.String())
+	q = Float64(1).RQuoRound(Float64(0), Up)
+	fmt.Printf("%T %g\n", q, q)
+	// Output:
+	// goarith.Int32 4
+	// goarith.Int32 -8
+	// goarith.Float64 +Inf
+}
+
+func ExampleFloat64_QuantizeTo() {
+	a := Float64(1.234).QuantizeTo(Float64(0.05))
+	fmt.Printf("%T %s\n", a, a.String())
+	a = Float64(-1.234).QuantizeTo(Float64(0.05))
+	fmt.Printf("%T %s\n", a, a.String())
+	a = Float64(1.234).QuantizeTo(Int32(1))
+	fmt.Printf("%T %s\n", a, a.String())
+	// Output:
+	// goarith.Float64 1.25
+	// goarith.Float64 -1.25
+	// goarith.Float64 1.0
+}
+
+func ExampleInt64_QuantizeTo() {
+	for _, a := range []Int64{1234, 1250, 1350, -1250} {
+		q := a.QuantizeTo(Int32(100))
+		fmt.Printf("%T %s\n", q, q.String())
+	}
+	x, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	q := (*BigInt)(x).QuantizeTo(Int64(1000000))
+	fmt.Printf("%T %s\n", q, q.String())
+	// Output:
+	// goarith.Int32 1200
+	// goarith.Int32 1200
+	// goarith.Int32 1400
+	// goarith.Int32 -1200
+	// *goarith.BigInt 123456789012345678901235000000
+}
+
+func TestQuantizeToZeroStep(t *testing.T) {
+	d, _ := ParseDecimal("1.25")
+	for _, c := range []struct {
+		a, step, want Number
+	}{
+		{Float64(5), Float64(0), Float64(math.NaN())},
+		{Int32(5), Float64(0), Float64(math.NaN())},
+		{Float64(5), Int32(0), Float64(math.NaN())},
+		{Int32(5), Int32(0), Int32(5)},
+		{Int64(1 << 40), Int64(0), Int64(1 << 40)},
+		{d, Int32(0), d},
+		{NewBigFloat(Float64(2.5), 0), Int32(0), NewBigFloat(Float64(2.5), 0)},
+	} {
+		if got := c.a.QuantizeTo(c.step); !Identical(got, c.want) {
+			t.Errorf("%s.QuantizeTo(%s) = %T %s, want %s", c.a, c.step, got, got, c.want)
+		}
+	}
+}
+
+func ExampleFloat64_Round() {
+	for _, a := range []Float64{2.5, -2.5, 3.5, -0.5, 2.4999, 1e300, -4.7} {
+		fmt.Printf("%s: %s %s %s %s\n", a.String(), a.Trunc().String(),
+			a.Floor().String(), a.Ceil().String(), a.Round().String())
+	}
+	b := Int64(-7)
+	fmt.Printf("%T %s\n", b.Round(), b.Round().String())
+	// Output:
+	// 2.5: 2.0 2.0 3.0 3.0
+	// -2.5: -2.0 -3.0 -2.0 -3.0
+	// 3.5: 3.0 3.0 4.0 4.0
+	// -0.5: -0.0 -1.0 -0.0 -1.0
+	// 2.4999: 2.0 2.0 3.0 2.0
+	// 1e+300: 1e+300 1e+300 1e+300 1e+300
+	// -4.7: -4.0 -5.0 -4.0 -5.0
+	// goarith.Int64 -7
+}
+
+func ExampleCeilDiv() {
+	for _, c := range [][2]Number{
+		{Int32(7), Int32(3)},
+		{Int32(-7), Int32(3)},
+		{Int32(7), Int32(-3)},
+		{Int32(-7), Int32(-3)},
+		{Int32(6), Int32(3)},
+		{Int32(-6), Int32(3)},
+		{Int32(0), Int32(-3)},
+	} {
+		fmt.Print(CeilDiv(c[0], c[1]), " ")
+	}
+	fmt.Println()
+	// Output:
+	// 3 -2 -2 3 2 -2 0
+}
+
+func TestCeilDiv(t *testing.T) {
+	x, _ := new(big.Int).SetString("100000000000000000001", 10)
+	if q := CeilDiv((*BigInt)(x), Int32(10)); q.String() != "10000000000000000001" {
+		t.Errorf("CeilDiv(%s, 10) = %s", x, q)
+	}
+	if q := CeilDiv(Int64(math.MinInt64), Int32(-1)); q.String() != "9223372036854775808" {
+		t.Errorf("CeilDiv(MinInt64, -1) = %s", q)
+	}
+	for a := int64(-20); a <= 20; a++ {
+		for b := int64(-6); b <= 6; b++ {
+			if b == 0 {
+				continue
+			}
+			want := a / b
+			if a%b != 0 && (a < 0) == (b < 0) {
+				want++
+			}
+			if q := CeilDiv(Int64(a), Int32(b)); q.Cmp(Int64(want)) != 0 {
+				t.Errorf("CeilDiv(%d, %d) = %s, want %d", a, b, q, want)
+			}
+		}
+	}
+}
+
+func ExampleDivRound() {
+	for _, c := range [][2]Number{
+		{Int32(7), Int32(2)},
+		{Int32(5), Int32(2)},
+		{Int32(-5), Int32(2)},
+		{Int32(5), Int32(3)},
+		{Float64(5), Int32(2)},
+	} {
+		fmt.Println(DivRound(c[0], c[1], ToNearestEven),
+			DivRound(c[0], c[1], ToNearestAway))
+	}
+	// Output:
+	// 4 4
+	// 2 3
+	// -2 -3
+	// 2 2
+	// 2 3
+}
+
+func TestDivRoundLarge(t *testing.T) {
+	// (10**30 + 5) / 10 is a tie which float64 cannot see.
+	x, _ := new(big.Int).SetString("1000000000000000000000000000005", 10)
+	for _, c := range []struct {
+		mode RoundingMode
+		want string
+	}{
+		{ToNearestEven, "100000000000000000000000000000"},
+		{ToNearestAway, "100000000000000000000000000001"},
+	} {
+		if q := DivRound((*BigInt)(x), Int32(10), c.mode); q.String() != c.want {
+			t.Errorf("DivRound(%s, 10, %d) = %s, want %s", x, c.mode, q, c.want)
+		}
+		if q := DivRound((*BigInt)(new(big.Int).Neg(x)), Int32(10), c.mode); q.String() != "-"+c.want {
+			t.Errorf("DivRound(-%s, 10, %d) = %s, want -%s", x, c.mode, q, c.want)
+		}
+	}
+}
+
+func ExampleRoundTo() {
+	d, _ := ParseDecimal("2.345")
+	for _, n := range []Number{Float64(2.345), d, Float64(-2.345), Int32(1234), Float64(1.005)} {
+		fmt.Println(RoundTo(n, 2, ToNearestAway), RoundTo(n, 1, ToNearestEven),
+			RoundTo(n, -2, ToNearestAway))
+	}
+	fmt.Println(RoundTo(NewBigFloat(Float64(2.345), 64), 2, Down), RoundTo(Int64(-1250), -2, ToNearestEven))
+	// Output:
+	// 2.35 2.3 0.0
+	// 2.35 2.3 0
+	// -2.35 -2.3 -0.0
+	// 1234 1234 1200
+	// 1.0 1.0 0.0
+	// 2.34 -1200
+}
+
+func ExampleInt64_FloorDivMod() {
+	// Python: divmod(13, 4), divmod(-13, 4), divmod(13, -4), divmod(-13, -4)
+	for _, c := range [][2]Int64{{13, 4}, {-13, 4}, {13, -4}, {-13, -4}, {-12, 4}} {
+		q, r := c[0].FloorDivMod(c[1])
+		fmt.Println(q, r)
+	}
+	// Python: divmod(-7.5, 2) and divmod(7.5, -2)
+	fmt.Println(Float64(-7.5).FloorDivMod(Int32(2)))
+	fmt.Println(Float64(7.5).FloorDivMod(Int32(-2)))
+	// Output:
+	// 3 1
+	// -4 3
+	// -4 -3
+	// 3 -1
+	// -3 0
+	// -4 0.5
+	// -4 -0.5
+}
+
+func TestFloorDivMod(t *testing.T) {
+	x, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	for _, a := range []Number{(*BigInt)(x), (*BigInt)(new(big.Int).Neg(x)),
+		Int32(math.MinInt32), Int64(math.MinInt64), Int32(7), Int32(-7)} {
+		for _, b := range []Number{Int32(3), Int32(-3), Int64(1 << 40),
+			(*BigInt)(new(big.Int).Neg(x)), NewBigFloat(Float64(-2.5), 0)} {
+			q, r := a.FloorDivMod(b)
+			// a = q*b + r, 0 <= |r| < |b| and r has the sign of b.
+			if a.Cmp(q.Mul(b).Add(r)) != 0 || r.CmpAbs(b) >= 0 ||
+				r.IsNegative() && !b.IsNegative() || r.IsPositive() && !b.IsPositive() {
+				t.Errorf("%s.FloorDivMod(%s) = %s, %s", a, b, q, r)
+			}
+		}
+	}
+}